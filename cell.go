@@ -0,0 +1,157 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import "math/bits"
+
+// MaxCellLevel is the deepest level a Cell can be at.
+const MaxCellLevel = 31
+
+// Cell is a square of a Hilbert space at some level of detail. At level k the space is split
+// into a 2^k by 2^k grid, and each of those squares is split into four children at level k+1.
+//
+// The first 2k bits of the index of any point on a Hilbert curve are the index, on the curve of
+// width 2^k, of the square the point is in. So a Cell is the same square whatever the width of
+// the Hilbert space it is used with, as long as the space is at least as deep as the cell.
+//
+// A Cell packs the index at its level into the top bits, followed by a single 1 bit and then
+// zeros. The fewer zeros the deeper the level, and all the descendants of a cell are within a
+// contiguous range of Cell values.
+type Cell uint64
+
+// NewCell returns the cell at level with index t on the Hilbert curve at that level, which must
+// be in the range [0, 4^level-1].
+func NewCell(level int, t uint64) (Cell, error) {
+	if level < 0 || level > MaxCellLevel {
+		return 0, ErrOutOfRange
+	}
+	if t>>uint(2*level) != 0 {
+		return 0, ErrOutOfRange
+	}
+	return Cell((t<<1 | 1) << uint(2*(MaxCellLevel-level))), nil
+}
+
+// lsb returns the lowest set bit, which marks the end of the index.
+func (c Cell) lsb() uint64 {
+	return uint64(c) & -uint64(c)
+}
+
+// IsValid returns true if c is a cell created by NewCell, or derived from one.
+func (c Cell) IsValid() bool {
+	return c != 0 && c>>(2*MaxCellLevel+1) == 0 && c.lsb()&0x5555555555555555 != 0
+}
+
+// Level returns the level of the cell, from 0 for the whole space to MaxCellLevel.
+func (c Cell) Level() int {
+	level := MaxCellLevel
+	for lsb := c.lsb(); lsb > 1; lsb >>= 2 {
+		level--
+	}
+	return level
+}
+
+// Index returns the cell's index on the Hilbert curve at its level.
+func (c Cell) Index() uint64 {
+	return uint64(c) >> uint(2*(MaxCellLevel-c.Level())+1)
+}
+
+// Parent returns the cell one level up which contains c. c must not be at level 0.
+func (c Cell) Parent() Cell {
+	return c.Ancestor(c.Level() - 1)
+}
+
+// Ancestor returns the cell at level which contains c. level must be within [0, c.Level()].
+func (c Cell) Ancestor(level int) Cell {
+	lsb := uint64(1) << uint(2*(MaxCellLevel-level))
+	return Cell(uint64(c)&-lsb | lsb)
+}
+
+// Children returns the four cells within c, one level down, in order along the curve. c must
+// not be at MaxCellLevel.
+func (c Cell) Children() [4]Cell {
+	lsb := c.lsb() >> 2
+	first := uint64(c) - c.lsb() + lsb
+	return [4]Cell{
+		Cell(first),
+		Cell(first + 2*lsb),
+		Cell(first + 4*lsb),
+		Cell(first + 6*lsb),
+	}
+}
+
+// Contains returns true if o is c, or within c at a deeper level.
+func (c Cell) Contains(o Cell) bool {
+	return o >= Cell(uint64(c)-(c.lsb()-1)) && o <= Cell(uint64(c)+(c.lsb()-1))
+}
+
+// log2N returns log2(N), the level of the cells which are single points of the space.
+func (s *Hilbert) log2N() int {
+	if s.N <= 1 {
+		return 0
+	}
+	return bits.Len(uint(s.N - 1))
+}
+
+// CellFromIndex returns the cell of the point with index t, as returned by MapInverse. The cell
+// is at level log2(N).
+func (s *Hilbert) CellFromIndex(t int) (Cell, error) {
+	if t < 0 || t >= s.N*s.N {
+		return 0, ErrOutOfRange
+	}
+	return NewCell(s.log2N(), uint64(t))
+}
+
+// CellFromPoint returns the cell at level which contains (x,y). level must be within
+// [0, log2(N)].
+func (s *Hilbert) CellFromPoint(x, y, level int) (Cell, error) {
+	levels := s.log2N()
+	if level < 0 || level > levels {
+		return 0, ErrOutOfRange
+	}
+	t, err := s.MapInverse(x, y)
+	if err != nil {
+		return 0, err
+	}
+	return NewCell(level, uint64(t)>>uint(2*(levels-level)))
+}
+
+// CellRange returns the range of indexes, as returned by MapInverse, of the points within c.
+// c must be no deeper than log2(N).
+func (s *Hilbert) CellRange(c Cell) (Range, error) {
+	levels := s.log2N()
+	if !c.IsValid() || c.Level() > levels {
+		return Range{}, ErrOutOfRange
+	}
+	shift := uint(2 * (levels - c.Level()))
+	start := int(c.Index() << shift)
+	return Range{Start: start, End: start + (1 << shift) - 1}, nil
+}
+
+// CellBox returns the box of points within c. c must be no deeper than log2(N).
+func (s *Hilbert) CellBox(c Cell) (Box, error) {
+	r, err := s.CellRange(c)
+	if err != nil {
+		return Box{}, err
+	}
+	x, y, err := s.Map(r.Start)
+	if err != nil {
+		return Box{}, err
+	}
+
+	// The curve enters the cell at one of its corners, which need not be the bottom left.
+	size := s.N >> uint(c.Level())
+	x, y = x&^(size-1), y&^(size-1)
+	return Box{XMin: x, YMin: y, XMax: x + size - 1, YMax: y + size - 1}, nil
+}
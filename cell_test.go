@@ -0,0 +1,158 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewCellErrors(t *testing.T) {
+	var testCases = []struct {
+		level   int
+		t       uint64
+		wantErr error
+	}{
+		{0, 0, nil},
+		{0, 1, ErrOutOfRange},
+		{1, 3, nil},
+		{1, 4, ErrOutOfRange},
+		{MaxCellLevel, 1<<62 - 1, nil},
+		{MaxCellLevel, 1 << 62, ErrOutOfRange},
+		{-1, 0, ErrOutOfRange},
+		{MaxCellLevel + 1, 0, ErrOutOfRange},
+	}
+
+	for _, tc := range testCases {
+		c, err := NewCell(tc.level, tc.t)
+		if err != tc.wantErr {
+			t.Errorf("NewCell(%d, %d) = (%x, %q) want err %q", tc.level, tc.t, c, err, tc.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !c.IsValid() || c.Level() != tc.level || c.Index() != tc.t {
+			t.Errorf("NewCell(%d, %d) = %x has level %d index %d", tc.level, tc.t, c, c.Level(), c.Index())
+		}
+	}
+}
+
+func TestCellHierarchy(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		leaf, err := NewCell(MaxCellLevel, uint64(r.Int63())>>1)
+		if err != nil {
+			t.Fatalf("NewCell failed: %s", err)
+		}
+
+		for c := leaf; c.Level() > 0; c = c.Parent() {
+			p := c.Parent()
+			if !p.IsValid() || p.Level() != c.Level()-1 || p.Index() != c.Index()/4 {
+				t.Fatalf("%x.Parent() = %x has level %d index %d", c, p, p.Level(), p.Index())
+			}
+			if p != leaf.Ancestor(p.Level()) {
+				t.Errorf("%x.Ancestor(%d) = %x want %x", leaf, p.Level(), leaf.Ancestor(p.Level()), p)
+			}
+			if !p.Contains(c) || !p.Contains(leaf) || c.Contains(p) {
+				t.Errorf("%x and parent %x do not contain each other correctly", c, p)
+			}
+			if children := p.Children(); children[c.Index()%4] != c {
+				t.Errorf("%x.Children() = %x does not have %x at %d", p, children, c, c.Index()%4)
+			}
+		}
+
+		if c, _ := NewCell(MaxCellLevel, leaf.Index()^1); leaf.Contains(c) {
+			t.Errorf("%x.Contains(%x) = true want false", leaf, c)
+		}
+	}
+}
+
+// TestCellMatchesHilbert checks cells are the same square, and so have the same range of
+// indexes and box, on every Hilbert space deep enough to hold them.
+func TestCellMatchesHilbert(t *testing.T) {
+	for _, n := range []int{1, 2, 4, 16, 64} {
+		s, err := NewHilbert(n)
+		if err != nil {
+			t.Fatalf("NewHilbert(%d) failed: %s", n, err)
+		}
+		levels := s.log2N()
+
+		for x := 0; x < n; x++ {
+			for y := 0; y < n; y++ {
+				d, _ := s.MapInverse(x, y)
+				leaf, err := s.CellFromIndex(d)
+				if err != nil {
+					t.Fatalf("N=%d: CellFromIndex(%d) failed: %s", n, d, err)
+				}
+				if leaf.Level() != levels || leaf.Index() != uint64(d) {
+					t.Errorf("N=%d: CellFromIndex(%d) = %x has level %d index %d", n, d, leaf, leaf.Level(), leaf.Index())
+				}
+
+				for level := 0; level <= levels; level++ {
+					c, err := s.CellFromPoint(x, y, level)
+					if err != nil {
+						t.Fatalf("N=%d: CellFromPoint(%d, %d, %d) failed: %s", n, x, y, level, err)
+					}
+					if c != leaf.Ancestor(level) {
+						t.Errorf("N=%d: CellFromPoint(%d, %d, %d) = %x want %x", n, x, y, level, c, leaf.Ancestor(level))
+					}
+
+					r, err := s.CellRange(c)
+					if err != nil || d < r.Start || d > r.End || r.End-r.Start+1 != (n*n)>>uint(2*level) {
+						t.Errorf("N=%d: CellRange(%x) = (%v, %v) does not hold %d", n, c, r, err, d)
+					}
+					b, err := s.CellBox(c)
+					if err != nil || !b.contains(x, y, 1) || b.XMax-b.XMin+1 != n>>uint(level) {
+						t.Errorf("N=%d: CellBox(%x) = (%v, %v) does not hold (%d,%d)", n, c, b, err, x, y)
+					}
+
+					// The same square at the same level on a smaller space.
+					if level > 0 {
+						small, _ := NewHilbert(1 << uint(level))
+						sx, sy := x>>uint(levels-level), y>>uint(levels-level)
+						if sc, _ := small.CellFromPoint(sx, sy, level); sc != c {
+							t.Errorf("N=%d: cell of (%d,%d) at level %d = %x, but %x for N=%d", n, x, y, level, c, sc, small.N)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCellHilbertErrors(t *testing.T) {
+	s, err := NewHilbert(16)
+	if err != nil {
+		t.Fatalf("NewHilbert(16) failed: %s", err)
+	}
+	deep, _ := NewCell(5, 0)
+
+	if _, err := s.CellFromIndex(256); err != ErrOutOfRange {
+		t.Errorf("CellFromIndex(256) = %q want %q", err, ErrOutOfRange)
+	}
+	if _, err := s.CellFromPoint(16, 0, 1); err != ErrOutOfRange {
+		t.Errorf("CellFromPoint(16, 0, 1) = %q want %q", err, ErrOutOfRange)
+	}
+	if _, err := s.CellFromPoint(0, 0, 5); err != ErrOutOfRange {
+		t.Errorf("CellFromPoint(0, 0, 5) = %q want %q", err, ErrOutOfRange)
+	}
+	if _, err := s.CellRange(deep); err != ErrOutOfRange {
+		t.Errorf("CellRange(%x) = %q want %q", deep, err, ErrOutOfRange)
+	}
+	if _, err := s.CellBox(Cell(0)); err != ErrOutOfRange {
+		t.Errorf("CellBox(0) = %q want %q", err, ErrOutOfRange)
+	}
+}
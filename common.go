@@ -22,8 +22,22 @@ var (
 	ErrNotPowerOfTwo   = errors.New("N must be a power of two")
 	ErrNotPowerOfThree = errors.New("N must be a power of three")
 	ErrOutOfRange      = errors.New("value is out of range")
+
+	ErrDimensionsNotPositive = errors.New("number of dimensions must be greater than zero")
+	ErrWrongDimensions       = errors.New("number of coordinates does not match the dimensions")
+	ErrOverflow              = errors.New("curve index does not fit in the index type")
+	ErrNotSupported          = errors.New("operation is not supported by this curve")
+	ErrLengthMismatch        = errors.New("slices must be the same length")
+	ErrBitsPerStep           = errors.New("bits per step must be 4 or 8")
+	ErrInvalidExtent         = errors.New("extent must have a positive finite width and height")
+	ErrInvalidPolygon        = errors.New("polygon must have at least three vertices")
 )
 
+// Point is a point in a two-dimension space.
+type Point struct {
+	X, Y int
+}
+
 // SpaceFilling represents a space-filling curve that can map points from one dimensions to two.
 type SpaceFilling interface {
 	// Map transforms a one dimension value, t, in the range [0, n^2-1] to coordinates on the
@@ -37,9 +51,103 @@ type SpaceFilling interface {
 	GetDimensions() (x, y int)
 }
 
+// SpaceFilling64 represents a space-filling curve that can map points from one dimensions to two,
+// using unsigned 64 bit indexes. This allows spaces of up to 2^32 by 2^32 to be used on any
+// platform.
+type SpaceFilling64 interface {
+	// Map transforms a one dimension value, t, in the range [0, n^2-1] to coordinates on the
+	// curve in the two-dimension space, where x and y are within [0,n-1].
+	Map(t uint64) (x, y uint32, err error)
+
+	// MapInverse transform coordinates on the curve from (x,y) to t.
+	MapInverse(x, y uint32) (t uint64, err error)
+
+	// GetDimensions returns the width and height of the 2D space.
+	GetDimensions() (x, y uint64)
+}
+
+// BatchSpaceFilling is implemented by space-filling curves that can map many values at once,
+// which is much faster than calling Map or MapInverse for each one.
+type BatchSpaceFilling interface {
+	// MapBatch transforms each value in ts to coordinates on the curve, storing them in the
+	// same position in xs and ys. The slices must be the same length.
+	MapBatch(ts []uint64, xs, ys []uint32) error
+
+	// MapInverseBatch transforms each pair of coordinates in xs and ys to a value on the curve,
+	// storing them in the same position in ts. The slices must be the same length.
+	MapInverseBatch(xs, ys []uint32, ts []uint64) error
+}
+
+// MapBatch transforms each value in ts to coordinates on the curve, storing them in the same
+// position in xs and ys. The batch methods are used if the curve implements BatchSpaceFilling,
+// otherwise Map is called for each value.
+func MapBatch(curve SpaceFilling, ts []uint64, xs, ys []uint32) error {
+	if b, ok := curve.(BatchSpaceFilling); ok {
+		return b.MapBatch(ts, xs, ys)
+	}
+
+	if len(xs) != len(ts) || len(ys) != len(ts) {
+		return ErrLengthMismatch
+	}
+	for i, t := range ts {
+		if t > uint64(maxInt) {
+			return ErrOutOfRange
+		}
+		x, y, err := curve.Map(int(t))
+		if err != nil {
+			return err
+		}
+		xs[i], ys[i] = uint32(x), uint32(y)
+	}
+	return nil
+}
+
+// MapInverseBatch transforms each pair of coordinates in xs and ys to a value on the curve,
+// storing them in the same position in ts. The batch methods are used if the curve implements
+// BatchSpaceFilling, otherwise MapInverse is called for each pair.
+func MapInverseBatch(curve SpaceFilling, xs, ys []uint32, ts []uint64) error {
+	if b, ok := curve.(BatchSpaceFilling); ok {
+		return b.MapInverseBatch(xs, ys, ts)
+	}
+
+	if len(ys) != len(xs) || len(ts) != len(xs) {
+		return ErrLengthMismatch
+	}
+	for i := range xs {
+		t, err := curve.MapInverse(int(xs[i]), int(ys[i]))
+		if err != nil {
+			return err
+		}
+		ts[i] = uint64(t)
+	}
+	return nil
+}
+
+// maxInt is the largest value an int can hold.
+const maxInt = int(^uint(0) >> 1)
+
 func b2i(b bool) int {
 	if b {
 		return 1
 	}
 	return 0
 }
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+	return b2i(n > 0)
+}
+
+// floorDiv2 returns n/2 rounded towards negative infinity.
+func floorDiv2(n int) int {
+	return n >> 1
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"testing"
+)
+
+// Check the batch curves implement the interface.
+var (
+	_ BatchSpaceFilling = &Hilbert{}
+	_ BatchSpaceFilling = &Peano{}
+	_ BatchSpaceFilling = &Morton{}
+)
+
+// TestMapBatchFallback checks MapBatch and MapInverseBatch work for curves with, and without,
+// their own batch methods.
+func TestMapBatchFallback(t *testing.T) {
+	h, _ := NewHilbert(16)
+	p, _ := NewPeano(9)
+	m, _ := NewMorton(16)
+	mo, _ := NewMoore(16)
+
+	for _, curve := range []SpaceFilling{h, p, m, mo} {
+		w, h := curve.GetDimensions()
+		size := w * h
+
+		ts := make([]uint64, size)
+		for d := range ts {
+			ts[d] = uint64(d)
+		}
+		xs, ys := make([]uint32, size), make([]uint32, size)
+
+		if err := MapBatch(curve, ts, xs, ys); err != nil {
+			t.Fatalf("%T: MapBatch returned error: %s", curve, err)
+		}
+		for d := range ts {
+			x, y, _ := curve.Map(d)
+			if xs[d] != uint32(x) || ys[d] != uint32(y) {
+				t.Errorf("%T: MapBatch(%d) = (%d, %d) want (%d, %d)", curve, d, xs[d], ys[d], x, y)
+			}
+		}
+
+		tsPrime := make([]uint64, size)
+		if err := MapInverseBatch(curve, xs, ys, tsPrime); err != nil {
+			t.Fatalf("%T: MapInverseBatch returned error: %s", curve, err)
+		}
+		for d := range ts {
+			if tsPrime[d] != ts[d] {
+				t.Errorf("%T: MapInverseBatch(%d, %d) = %d want %d", curve, xs[d], ys[d], tsPrime[d], ts[d])
+			}
+		}
+
+		if err := MapBatch(curve, []uint64{uint64(size)}, []uint32{0}, []uint32{0}); err != ErrOutOfRange {
+			t.Errorf("%T: MapBatch(%d) = %q want %q", curve, size, err, ErrOutOfRange)
+		}
+		if err := MapBatch(curve, []uint64{0}, nil, []uint32{0}); err != ErrLengthMismatch {
+			t.Errorf("%T: MapBatch with short xs = %q want %q", curve, err, ErrLengthMismatch)
+		}
+		if err := MapInverseBatch(curve, []uint32{uint32(w)}, []uint32{0}, []uint64{0}); err != ErrOutOfRange {
+			t.Errorf("%T: MapInverseBatch(%d, 0) = %q want %q", curve, w, err, ErrOutOfRange)
+		}
+		if err := MapInverseBatch(curve, []uint32{0}, []uint32{0}, nil); err != ErrLengthMismatch {
+			t.Errorf("%T: MapInverseBatch with short ts = %q want %q", curve, err, ErrLengthMismatch)
+		}
+	}
+}
+
+// batchBenchmarks are the curves with their own batch methods, as a caller holding a
+// SpaceFilling sees them. Each has about 64K points.
+func batchBenchmarks(b *testing.B) []struct {
+	name  string
+	curve SpaceFilling
+} {
+	h, err := NewHilbert(256)
+	if err != nil {
+		b.Fatalf("NewHilbert(256) failed: %s", err)
+	}
+	p, err := NewPeano(243)
+	if err != nil {
+		b.Fatalf("NewPeano(243) failed: %s", err)
+	}
+	m, err := NewMorton(256)
+	if err != nil {
+		b.Fatalf("NewMorton(256) failed: %s", err)
+	}
+	return []struct {
+		name  string
+		curve SpaceFilling
+	}{{"Hilbert", h}, {"Peano", p}, {"Morton", m}}
+}
+
+// batchBenchmarkSlices returns every value of the curve, and the coordinates of every point.
+func batchBenchmarkSlices(curve SpaceFilling) (ts []uint64, xs, ys []uint32) {
+	w, h := curve.GetDimensions()
+	ts = make([]uint64, w*h)
+	xs, ys = make([]uint32, w*h), make([]uint32, w*h)
+	for d := range ts {
+		x, y, _ := curve.Map(d)
+		ts[d], xs[d], ys[d] = uint64(d), uint32(x), uint32(y)
+	}
+	return ts, xs, ys
+}
+
+// BenchmarkSpaceFillingMap calls Map for each value, which MapBatch is compared against.
+func BenchmarkSpaceFillingMap(b *testing.B) {
+	for _, bc := range batchBenchmarks(b) {
+		curve := bc.curve
+		ts, xs, ys := batchBenchmarkSlices(curve)
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j, t := range ts {
+					x, y, _ := curve.Map(int(t))
+					xs[j], ys[j] = uint32(x), uint32(y)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSpaceFillingMapBatch(b *testing.B) {
+	for _, bc := range batchBenchmarks(b) {
+		curve := bc.curve
+		ts, xs, ys := batchBenchmarkSlices(curve)
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MapBatch(curve, ts, xs, ys)
+			}
+		})
+	}
+}
+
+// BenchmarkSpaceFillingMapInverse calls MapInverse for each point, which MapInverseBatch is
+// compared against.
+func BenchmarkSpaceFillingMapInverse(b *testing.B) {
+	for _, bc := range batchBenchmarks(b) {
+		curve := bc.curve
+		ts, xs, ys := batchBenchmarkSlices(curve)
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range xs {
+					t, _ := curve.MapInverse(int(xs[j]), int(ys[j]))
+					ts[j] = uint64(t)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSpaceFillingMapInverseBatch(b *testing.B) {
+	for _, bc := range batchBenchmarks(b) {
+		curve := bc.curve
+		ts, xs, ys := batchBenchmarkSlices(curve)
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MapInverseBatch(curve, xs, ys, ts)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import "sort"
+
+// Vertex is a point in the continuous plane of a space, where the point (x,y) of the space is
+// the unit square from (x,y) to (x+1,y+1).
+type Vertex struct {
+	X, Y float64
+}
+
+// Polygon is a simple polygon, which may be concave, given by its vertices in order. The last
+// vertex is joined back to the first.
+type Polygon []Vertex
+
+// RegionCoverer finds sets of cells, at mixed levels, which approximate a polygon. It is
+// similar to S2's RegionCoverer.
+type RegionCoverer struct {
+	MinLevel int // Cells are no larger than this level.
+	MaxLevel int // Cells are no smaller than this level, which must be at most log2(N).
+
+	// MaxCells is the number of cells to aim for. Larger cells are only split while the
+	// result stays within MaxCells, but all the cells at MinLevel touching the polygon are
+	// returned even if there are more. If MaxCells is zero there is no limit.
+	MaxCells int
+}
+
+// Covering returns the cells of s whose union covers the polygon, in increasing order. Cells
+// which are only partly within the polygon are included, so the covering may extend outside it.
+func (rc *RegionCoverer) Covering(s *Hilbert, p Polygon) ([]Cell, error) {
+	return rc.cover(s, p, false)
+}
+
+// InteriorCovering returns the cells of s which are wholly within the polygon, in increasing
+// order. Parts of the polygon along its edges may not be covered.
+func (rc *RegionCoverer) InteriorCovering(s *Hilbert, p Polygon) ([]Cell, error) {
+	return rc.cover(s, p, true)
+}
+
+// candidate is a cell being considered for a covering.
+type candidate struct {
+	cell   Cell
+	inside bool // Wholly within the polygon.
+}
+
+// coverer holds the state while covering a polygon.
+type coverer struct {
+	s *Hilbert
+	p Polygon
+}
+
+func (rc *RegionCoverer) cover(s *Hilbert, p Polygon, interior bool) ([]Cell, error) {
+	if len(p) < 3 {
+		return nil, ErrInvalidPolygon
+	}
+	if rc.MinLevel < 0 || rc.MinLevel > rc.MaxLevel || rc.MaxLevel > s.log2N() {
+		return nil, ErrOutOfRange
+	}
+
+	c := &coverer{s: s, p: p}
+	root, _ := NewCell(0, 0)
+	inside, touches := p.classify(Box{0, 0, s.N - 1, s.N - 1})
+	if !touches {
+		return nil, nil
+	}
+
+	// Start from the cells at MinLevel touching the polygon.
+	queue := []candidate{{cell: root, inside: inside}}
+	for level := 0; level < rc.MinLevel; level++ {
+		var next []candidate
+		for _, cand := range queue {
+			next = append(next, c.children(cand.cell)...)
+		}
+		queue = next
+	}
+
+	// Split the largest cells first, while the number of cells stays within MaxCells. The
+	// queue is always in level order, as children are appended to the end.
+	var result []Cell
+	for len(queue) > 0 {
+		cand := queue[0]
+		queue = queue[1:]
+
+		if cand.inside || cand.cell.Level() == rc.MaxLevel {
+			if cand.inside || !interior {
+				result = append(result, cand.cell)
+			}
+			continue
+		}
+
+		children := c.children(cand.cell)
+		if rc.MaxCells > 0 && len(result)+len(queue)+len(children) > rc.MaxCells {
+			if !interior {
+				result = append(result, cand.cell)
+			}
+			continue
+		}
+		queue = append(queue, children...)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return normalize(result, rc.MinLevel), nil
+}
+
+// children returns the children of the cell which touch the polygon.
+func (c *coverer) children(parent Cell) []candidate {
+	var children []candidate
+	for _, cell := range parent.Children() {
+		box, err := c.s.CellBox(cell)
+		if err != nil {
+			panic("assertion failure: " + err.Error())
+		}
+		inside, touches := c.p.classify(box)
+		if touches {
+			children = append(children, candidate{cell: cell, inside: inside})
+		}
+	}
+	return children
+}
+
+// normalize replaces any four sibling cells with their parent, as long as the parent is no
+// larger than minLevel. cells must be sorted.
+func normalize(cells []Cell, minLevel int) []Cell {
+	out := cells[:0]
+	for _, cell := range cells {
+		out = append(out, cell)
+		for len(out) >= 4 {
+			last := out[len(out)-1]
+			if last.Level() <= minLevel {
+				break
+			}
+			parent := last.Parent()
+			var siblings [4]Cell
+			copy(siblings[:], out[len(out)-4:])
+			if siblings != parent.Children() {
+				break
+			}
+			out = append(out[:len(out)-4], parent)
+		}
+	}
+	return out
+}
+
+// CellRanges returns the ranges of indexes, as returned by MapInverse, covered by the cells,
+// in increasing order with touching ranges joined. This turns a covering into range scans over
+// keys sorted by their index on the curve.
+func (s *Hilbert) CellRanges(cells []Cell) ([]Range, error) {
+	ranges := make([]Range, len(cells))
+	for i, cell := range cells {
+		r, err := s.CellRange(cell)
+		if err != nil {
+			return nil, err
+		}
+		ranges[i] = r
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start < ranges[j].Start })
+
+	r := &ranger{}
+	for _, cr := range ranges {
+		if last := len(r.ranges) - 1; last >= 0 && r.ranges[last].End >= cr.End {
+			continue // within a larger cell
+		}
+		r.add(cr.Start, cr.End)
+	}
+	return r.ranges, nil
+}
+
+// classify returns whether the box is wholly inside the polygon, and whether any of its
+// interior is inside the polygon. The box covers the unit squares of its points.
+func (p Polygon) classify(b Box) (inside, touches bool) {
+	x0, y0 := float64(b.XMin), float64(b.YMin)
+	x1, y1 := float64(b.XMax+1), float64(b.YMax+1)
+
+	crosses := false
+	for i := range p {
+		if segmentCrossesBox(p[i], p[(i+1)%len(p)], x0, y0, x1, y1) {
+			crosses = true
+			break
+		}
+	}
+
+	centre := p.contains((x0+x1)/2, (y0+y1)/2)
+	return centre && !crosses, centre || crosses
+}
+
+// contains returns true if (x,y) is inside the polygon, by the even-odd rule.
+func (p Polygon) contains(x, y float64) bool {
+	in := false
+	j := len(p) - 1
+	for i := range p {
+		a, b := p[i], p[j]
+		if (a.Y > y) != (b.Y > y) && x < (b.X-a.X)*(y-a.Y)/(b.Y-a.Y)+a.X {
+			in = !in
+		}
+		j = i
+	}
+	return in
+}
+
+// segmentCrossesBox returns true if the segment from a to b passes through the inside of the
+// box from (x0,y0) to (x1,y1), using Liang-Barsky clipping. Segments which only touch the edges
+// of the box do not cross it.
+func segmentCrossesBox(a, b Vertex, x0, y0, x1, y1 float64) bool {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	tmin, tmax := 0.0, 1.0
+	for _, edge := range [4][2]float64{
+		{-dx, a.X - x0},
+		{dx, x1 - a.X},
+		{-dy, a.Y - y0},
+		{dy, y1 - a.Y},
+	} {
+		p, q := edge[0], edge[1]
+		if p == 0 {
+			if q <= 0 {
+				return false // parallel and outside, or along an edge
+			}
+			continue
+		}
+		t := q / p
+		if p < 0 {
+			if t > tmin {
+				tmin = t
+			}
+		} else if t < tmax {
+			tmax = t
+		}
+	}
+	return tmin < tmax
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var coverTestPolygons = map[string]Polygon{
+	"triangle": {{3.5, 2}, {60, 10.25}, {20, 50}},
+	"square":   {{16, 16}, {48, 16}, {48, 48}, {16, 48}},
+	"concave":  {{5, 5}, {60, 5}, {60, 60}, {40, 60}, {40, 20}, {25, 20}, {25, 60}, {5, 60}},
+	"sliver":   {{0, 0}, {64, 63.5}, {64, 64}},
+	"outside":  {{70, 70}, {80, 70}, {80, 80}},
+}
+
+// randomPolygon returns a star shaped polygon around a random centre.
+func randomPolygon(r *rand.Rand, n float64) Polygon {
+	cx, cy := r.Float64()*n, r.Float64()*n
+	var p Polygon
+	for a := 0.0; a < 2*math.Pi; a += 0.2 + r.Float64() {
+		d := r.Float64() * n / 2
+		p = append(p, Vertex{cx + d*math.Cos(a), cy + d*math.Sin(a)})
+	}
+	return p
+}
+
+// checkCovering checks the cells are sorted, disjoint and within the levels, and that every
+// point of the space within the polygon is covered, or for an interior covering that every
+// point covered is within the polygon.
+func checkCovering(t *testing.T, s *Hilbert, rc *RegionCoverer, p Polygon, cells []Cell, interior bool) {
+	t.Helper()
+
+	covered := make(map[int]bool)
+	for i, c := range cells {
+		if c.Level() < rc.MinLevel || c.Level() > rc.MaxLevel {
+			t.Errorf("%+v %v: cell %x at level %d", rc, p, c, c.Level())
+		}
+		if i > 0 && (cells[i-1] >= c || cells[i-1].Contains(c) || c.Contains(cells[i-1])) {
+			t.Errorf("%+v %v: cells %x and %x not sorted or overlap", rc, p, cells[i-1], c)
+		}
+		r, err := s.CellRange(c)
+		if err != nil {
+			t.Fatalf("CellRange(%x) failed: %s", c, err)
+		}
+		for d := r.Start; d <= r.End; d++ {
+			covered[d] = true
+		}
+	}
+
+	for x := 0; x < s.N; x++ {
+		for y := 0; y < s.N; y++ {
+			d, _ := s.MapInverse(x, y)
+			inside, touches := p.classify(Box{x, y, x, y})
+			if interior && covered[d] && !inside {
+				t.Errorf("%+v %v: interior covering includes (%d,%d)", rc, p, x, y)
+			}
+			if !interior && touches && !covered[d] {
+				t.Errorf("%+v %v: covering misses (%d,%d)", rc, p, x, y)
+			}
+		}
+	}
+}
+
+func TestRegionCoverer(t *testing.T) {
+	s, err := NewHilbert(64)
+	if err != nil {
+		t.Fatalf("NewHilbert(64) failed: %s", err)
+	}
+
+	coverers := []*RegionCoverer{
+		{MinLevel: 0, MaxLevel: 6},
+		{MinLevel: 0, MaxLevel: 6, MaxCells: 8},
+		{MinLevel: 2, MaxLevel: 4, MaxCells: 20},
+		{MinLevel: 3, MaxLevel: 3},
+		{MinLevel: 0, MaxLevel: 0},
+	}
+
+	for name, p := range coverTestPolygons {
+		for _, rc := range coverers {
+			cells, err := rc.Covering(s, p)
+			if err != nil {
+				t.Fatalf("%+v: Covering(%s) failed: %s", rc, name, err)
+			}
+			checkCovering(t, s, rc, p, cells, false)
+
+			interior, err := rc.InteriorCovering(s, p)
+			if err != nil {
+				t.Fatalf("%+v: InteriorCovering(%s) failed: %s", rc, name, err)
+			}
+			checkCovering(t, s, rc, p, interior, true)
+
+			if rc.MinLevel == 0 && rc.MaxCells > 0 {
+				if len(cells) > rc.MaxCells || len(interior) > rc.MaxCells {
+					t.Errorf("%+v: %s has %d and %d cells", rc, name, len(cells), len(interior))
+				}
+			}
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		p := randomPolygon(r, 64)
+		rc := &RegionCoverer{MinLevel: r.Intn(3), MaxLevel: 3 + r.Intn(4), MaxCells: r.Intn(30)}
+		cells, err := rc.Covering(s, p)
+		if err != nil {
+			t.Fatalf("%+v: Covering(%v) failed: %s", rc, p, err)
+		}
+		checkCovering(t, s, rc, p, cells, false)
+
+		interior, err := rc.InteriorCovering(s, p)
+		if err != nil {
+			t.Fatalf("%+v: InteriorCovering(%v) failed: %s", rc, p, err)
+		}
+		checkCovering(t, s, rc, p, interior, true)
+	}
+}
+
+func TestRegionCovererExact(t *testing.T) {
+	s, err := NewHilbert(64)
+	if err != nil {
+		t.Fatalf("NewHilbert(64) failed: %s", err)
+	}
+	rc := &RegionCoverer{MaxLevel: 6}
+
+	// An aligned square is one cell, in both modes.
+	p := Polygon{{32, 32}, {48, 32}, {48, 48}, {32, 48}}
+	want, _ := s.CellFromPoint(32, 32, 2)
+	for _, f := range []func(*Hilbert, Polygon) ([]Cell, error){rc.Covering, rc.InteriorCovering} {
+		cells, err := f(s, p)
+		if err != nil || len(cells) != 1 || cells[0] != want {
+			t.Errorf("covering of %v = (%x, %v) want [%x]", p, cells, err, want)
+		}
+	}
+}
+
+func TestRegionCovererErrors(t *testing.T) {
+	s, err := NewHilbert(16)
+	if err != nil {
+		t.Fatalf("NewHilbert(16) failed: %s", err)
+	}
+	p := coverTestPolygons["triangle"]
+
+	var testCases = []struct {
+		rc      RegionCoverer
+		p       Polygon
+		wantErr error
+	}{
+		{RegionCoverer{MaxLevel: 4}, p, nil},
+		{RegionCoverer{MaxLevel: 5}, p, ErrOutOfRange},
+		{RegionCoverer{MinLevel: 3, MaxLevel: 2}, p, ErrOutOfRange},
+		{RegionCoverer{MinLevel: -1, MaxLevel: 2}, p, ErrOutOfRange},
+		{RegionCoverer{MaxLevel: 4}, p[:2], ErrInvalidPolygon},
+	}
+
+	for _, tc := range testCases {
+		if _, err := tc.rc.Covering(s, tc.p); err != tc.wantErr {
+			t.Errorf("%+v: Covering(%v) = %q want %q", tc.rc, tc.p, err, tc.wantErr)
+		}
+		if _, err := tc.rc.InteriorCovering(s, tc.p); err != tc.wantErr {
+			t.Errorf("%+v: InteriorCovering(%v) = %q want %q", tc.rc, tc.p, err, tc.wantErr)
+		}
+	}
+}
+
+func TestCellRanges(t *testing.T) {
+	s, err := NewHilbert(16)
+	if err != nil {
+		t.Fatalf("NewHilbert(16) failed: %s", err)
+	}
+
+	cell := func(level int, t uint64) Cell {
+		c, err := NewCell(level, t)
+		if err != nil {
+			panic(err)
+		}
+		return c
+	}
+
+	var testCases = []struct {
+		cells []Cell
+		want  []Range
+	}{
+		{nil, nil},
+		{[]Cell{cell(0, 0)}, []Range{{0, 255}}},
+		{[]Cell{cell(2, 3), cell(1, 0), cell(2, 5)}, []Range{{0, 63}, {80, 95}}},
+		{[]Cell{cell(4, 17), cell(4, 16), cell(3, 5)}, []Range{{16, 17}, {20, 23}}},
+		{[]Cell{cell(1, 1), cell(3, 20)}, []Range{{64, 127}}},
+	}
+
+	for _, tc := range testCases {
+		got, err := s.CellRanges(tc.cells)
+		if err != nil {
+			t.Errorf("CellRanges(%x) failed: %s", tc.cells, err)
+			continue
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("CellRanges(%x) = %v want %v", tc.cells, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("CellRanges(%x) = %v want %v", tc.cells, got, tc.want)
+				break
+			}
+		}
+	}
+
+	if _, err := s.CellRanges([]Cell{cell(5, 0)}); err != ErrOutOfRange {
+		t.Errorf("CellRanges with a deep cell = %q want %q", err, ErrOutOfRange)
+	}
+}
+
+func BenchmarkRegionCoverer(b *testing.B) {
+	s, err := NewHilbert(1 << 15)
+	if err != nil {
+		b.Fatalf("NewHilbert failed: %s", err)
+	}
+	rc := &RegionCoverer{MaxLevel: 15, MaxCells: 64}
+	p := Polygon{{100, 200}, {30000, 1500}, {20000, 24000}, {10000, 10000}, {3000, 30000}}
+
+	for i := 0; i < b.N; i++ {
+		rc.Covering(s, p)
+	}
+}
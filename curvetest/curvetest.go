@@ -0,0 +1,270 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package curvetest checks that implementations of hilbert.SpaceFilling behave correctly, for
+// use in their tests:
+//
+//	func TestCurve(t *testing.T) {
+//		curvetest.Verify(t, NewMyCurve(64), curvetest.Options{Continuous: true})
+//	}
+package curvetest
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/hilbert"
+)
+
+// Options controls which checks Verify makes.
+type Options struct {
+	// Continuous checks that each cell on the curve is next to the one before it, sharing an
+	// edge. It should be false for curves which jump, such as Morton.
+	Continuous bool
+
+	// Diagonal allows a continuous curve to step to a cell sharing only a corner.
+	Diagonal bool
+
+	// MaxCells limits the number of indexes checked. If the space has more cells, a random
+	// sample of MaxCells indexes is checked, along with the first and last. The default is 1<<16.
+	MaxCells int
+
+	// Seed for the random sample of indexes.
+	Seed int64
+}
+
+// maxErrors is the number of errors reported by each check before it gives up.
+const maxErrors = 10
+
+// Verify checks that the curve is a valid space-filling curve, reporting any problems to t:
+//
+//   - GetDimensions returns a positive width and height, whose product fits in an int.
+//   - Map and MapInverse are a bijection between [0, width*height) and the cells of the space.
+//   - With Options.Continuous, each cell is next to the one before it on the curve.
+//   - Map and MapInverse return hilbert.ErrOutOfRange for values outside the space.
+//   - If the curve implements hilbert.BatchSpaceFilling, the batch methods agree with Map and
+//     MapInverse.
+func Verify(t testing.TB, curve hilbert.SpaceFilling, opts Options) {
+	t.Helper()
+
+	w, h := curve.GetDimensions()
+	if w <= 0 || h <= 0 {
+		t.Errorf("%T: GetDimensions() = %d, %d want positive dimensions", curve, w, h)
+		return
+	}
+	if w > maxInt/h {
+		t.Errorf("%T: GetDimensions() = %d, %d whose product does not fit in an int", curve, w, h)
+		return
+	}
+	if w2, h2 := curve.GetDimensions(); w2 != w || h2 != h {
+		t.Errorf("%T: GetDimensions() = %d, %d then %d, %d want the same each time", curve, w, h, w2, h2)
+	}
+
+	if opts.MaxCells <= 0 {
+		opts.MaxCells = 1 << 16
+	}
+	ts := indexes(w*h, opts.MaxCells, opts.Seed)
+
+	c := &checker{t: t, curve: curve}
+	verifyBijection(c, curve, w, h, ts)
+	if opts.Continuous {
+		verifyContinuity(c, curve, ts, w*h, opts.Diagonal)
+	}
+	verifyOutOfRange(c, curve, w, h)
+	if b, ok := curve.(hilbert.BatchSpaceFilling); ok {
+		verifyBatch(c, curve, b, ts)
+	}
+}
+
+// maxInt is the largest value an int can hold.
+const maxInt = int(^uint(0) >> 1)
+
+// checker reports errors, giving up on a check once it has reported maxErrors.
+type checker struct {
+	t      testing.TB
+	curve  hilbert.SpaceFilling
+	errors int
+}
+
+// errorf reports an error, and returns false once too many errors have been reported by the
+// current check.
+func (c *checker) errorf(format string, args ...interface{}) bool {
+	c.t.Helper()
+	c.errors++
+	if c.errors > maxErrors {
+		return false
+	}
+	c.t.Errorf("%T: "+format, append([]interface{}{c.curve}, args...)...)
+	if c.errors == maxErrors {
+		c.t.Errorf("%T: too many errors, skipping the rest of this check", c.curve)
+		return false
+	}
+	return true
+}
+
+// start starts a new check.
+func (c *checker) start() {
+	c.errors = 0
+}
+
+// indexes returns the indexes to check on a curve of size cells. These are all the indexes if
+// there are at most max, otherwise a random sample of them with the first and last.
+func indexes(size, max int, seed int64) []int {
+	if size <= max {
+		ts := make([]int, size)
+		for i := range ts {
+			ts[i] = i
+		}
+		return ts
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	ts := []int{0, size - 1}
+	for len(ts) < max {
+		ts = append(ts, rng.Intn(size))
+	}
+	return ts
+}
+
+// verifyBijection checks that each index maps to a cell within the space, which maps back to
+// it. Then no two indexes share a cell, so when all the indexes are checked every cell is
+// reached exactly once.
+func verifyBijection(c *checker, curve hilbert.SpaceFilling, w, h int, ts []int) {
+	c.t.Helper()
+	c.start()
+
+	for _, t := range ts {
+		x, y, err := curve.Map(t)
+		if err != nil {
+			if !c.errorf("Map(%d) returned error: %s", t, err) {
+				return
+			}
+			continue
+		}
+		if x < 0 || x >= w || y < 0 || y >= h {
+			if !c.errorf("Map(%d) = (%d, %d) which is outside the %dx%d space", t, x, y, w, h) {
+				return
+			}
+			continue
+		}
+
+		t2, err := curve.MapInverse(x, y)
+		if err != nil {
+			if !c.errorf("MapInverse(%d, %d) returned error: %s", x, y, err) {
+				return
+			}
+			continue
+		}
+		if t2 != t {
+			if !c.errorf("Map(%d) = (%d, %d) but MapInverse(%d, %d) = %d", t, x, y, x, y, t2) {
+				return
+			}
+		}
+	}
+}
+
+// verifyContinuity checks that each index maps to a cell next to the one before it.
+func verifyContinuity(c *checker, curve hilbert.SpaceFilling, ts []int, size int, diagonal bool) {
+	c.t.Helper()
+	c.start()
+
+	for _, t := range ts {
+		if t+1 >= size {
+			continue
+		}
+		x1, y1, err1 := curve.Map(t)
+		x2, y2, err2 := curve.Map(t + 1)
+		if err1 != nil || err2 != nil {
+			continue // Already reported by verifyBijection.
+		}
+
+		dx, dy := abs(x2-x1), abs(y2-y1)
+		if dx+dy == 1 || (diagonal && dx == 1 && dy == 1) {
+			continue
+		}
+		if !c.errorf("Map(%d) = (%d, %d) and Map(%d) = (%d, %d) are not next to each other", t, x1, y1, t+1, x2, y2) {
+			return
+		}
+	}
+}
+
+// verifyOutOfRange checks that values just outside the space return hilbert.ErrOutOfRange.
+func verifyOutOfRange(c *checker, curve hilbert.SpaceFilling, w, h int) {
+	c.t.Helper()
+	c.start()
+
+	for _, t := range []int{-1, w * h} {
+		if _, _, err := curve.Map(t); err != hilbert.ErrOutOfRange {
+			c.errorf("Map(%d) err = %v want %v", t, err, hilbert.ErrOutOfRange)
+		}
+	}
+	for _, p := range [][2]int{{-1, 0}, {0, -1}, {w, 0}, {0, h}, {w, h}} {
+		if _, err := curve.MapInverse(p[0], p[1]); err != hilbert.ErrOutOfRange {
+			c.errorf("MapInverse(%d, %d) err = %v want %v", p[0], p[1], err, hilbert.ErrOutOfRange)
+		}
+	}
+}
+
+// verifyBatch checks that the batch methods agree with Map and MapInverse.
+func verifyBatch(c *checker, curve hilbert.SpaceFilling, b hilbert.BatchSpaceFilling, ts []int) {
+	c.t.Helper()
+	c.start()
+
+	bts := make([]uint64, len(ts))
+	for i, t := range ts {
+		bts[i] = uint64(t)
+	}
+	xs := make([]uint32, len(ts))
+	ys := make([]uint32, len(ts))
+	if err := b.MapBatch(bts, xs, ys); err != nil {
+		c.errorf("MapBatch returned error: %s", err)
+		return
+	}
+
+	for i, t := range ts {
+		x, y, err := curve.Map(t)
+		if err != nil {
+			continue // Already reported by verifyBijection.
+		}
+		if int(xs[i]) != x || int(ys[i]) != y {
+			if !c.errorf("MapBatch gave %d -> (%d, %d) want (%d, %d)", t, xs[i], ys[i], x, y) {
+				return
+			}
+		}
+	}
+
+	inv := make([]uint64, len(ts))
+	if err := b.MapInverseBatch(xs, ys, inv); err != nil {
+		c.errorf("MapInverseBatch returned error: %s", err)
+		return
+	}
+	for i := range ts {
+		t, err := curve.MapInverse(int(xs[i]), int(ys[i]))
+		if err != nil {
+			continue // Already reported by verifyBijection.
+		}
+		if inv[i] != uint64(t) {
+			if !c.errorf("MapInverseBatch gave (%d, %d) -> %d want %d", xs[i], ys[i], inv[i], t) {
+				return
+			}
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
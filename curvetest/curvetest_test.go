@@ -0,0 +1,225 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package curvetest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/hilbert"
+)
+
+func TestVerifyCurves(t *testing.T) {
+	var testCases = []struct {
+		create func() (hilbert.SpaceFilling, error)
+		opts   Options
+	}{
+		{func() (hilbert.SpaceFilling, error) { return hilbert.NewHilbert(1) }, Options{Continuous: true}},
+		{func() (hilbert.SpaceFilling, error) { return hilbert.NewHilbert(32) }, Options{Continuous: true}},
+		{func() (hilbert.SpaceFilling, error) { return hilbert.NewHilbertTable(64, 8) }, Options{Continuous: true}},
+		{func() (hilbert.SpaceFilling, error) { return hilbert.NewPeano(27) }, Options{Continuous: true}},
+		{func() (hilbert.SpaceFilling, error) { return hilbert.NewMoore(32) }, Options{Continuous: true}},
+		{func() (hilbert.SpaceFilling, error) { return hilbert.NewMorton(32) }, Options{}},
+		{func() (hilbert.SpaceFilling, error) { return hilbert.NewHilbertRect(1, 1) }, Options{Continuous: true}},
+		{func() (hilbert.SpaceFilling, error) { return hilbert.NewHilbertRect(37, 21) }, Options{Continuous: true, Diagonal: true}},
+		{func() (hilbert.SpaceFilling, error) { return hilbert.NewHilbert(1 << 12) }, Options{Continuous: true, MaxCells: 1000}},
+	}
+
+	for _, tc := range testCases {
+		curve, err := tc.create()
+		if err != nil {
+			t.Fatalf("creating curve failed: %s", err)
+		}
+		Verify(t, curve, tc.opts)
+	}
+}
+
+// recorder is a testing.TB which records the errors reported to it.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// swapped swaps the first two indexes in Map, but not in MapInverse.
+type swapped struct {
+	hilbert.SpaceFilling
+}
+
+func (s swapped) Map(t int) (x, y int, err error) {
+	if t == 0 || t == 1 {
+		t = 1 - t
+	}
+	return s.SpaceFilling.Map(t)
+}
+
+// outside maps the last index outside the space.
+type outside struct {
+	hilbert.SpaceFilling
+}
+
+func (s outside) Map(t int) (x, y int, err error) {
+	if w, h := s.GetDimensions(); t == w*h-1 {
+		return w, 0, nil
+	}
+	return s.SpaceFilling.Map(t)
+}
+
+// wrongError returns the wrong error for values outside the space.
+type wrongError struct {
+	hilbert.SpaceFilling
+}
+
+func (s wrongError) MapInverse(x, y int) (t int, err error) {
+	t, err = s.SpaceFilling.MapInverse(x, y)
+	if err != nil {
+		err = errors.New("bad coordinates")
+	}
+	return t, err
+}
+
+// badBatch has a MapBatch which is off by one.
+type badBatch struct {
+	*hilbert.Hilbert
+}
+
+func (s badBatch) MapBatch(ts []uint64, xs, ys []uint32) error {
+	err := s.Hilbert.MapBatch(ts, xs, ys)
+	for i := range xs {
+		xs[i]++
+	}
+	return err
+}
+
+// empty has no cells.
+type empty struct {
+	hilbert.SpaceFilling
+}
+
+func (s empty) GetDimensions() (int, int) {
+	return 0, 8
+}
+
+// broken maps every index to the same cell.
+type broken struct {
+	hilbert.SpaceFilling
+}
+
+func (s broken) Map(t int) (x, y int, err error) {
+	if w, h := s.GetDimensions(); t < 0 || t >= w*h {
+		return -1, -1, hilbert.ErrOutOfRange
+	}
+	return 0, 0, nil
+}
+
+func TestVerifyFailures(t *testing.T) {
+	h, _ := hilbert.NewHilbert(8)
+	m, _ := hilbert.NewMorton(8)
+
+	var testCases = []struct {
+		curve hilbert.SpaceFilling
+		opts  Options
+		want  []string // Substrings of the errors, one for each error.
+	}{
+		{swapped{h}, Options{}, []string{
+			"Map(0) = (0, 1) but MapInverse(0, 1) = 1",
+			"Map(1) = (0, 0) but MapInverse(0, 0) = 0",
+		}},
+		{outside{h}, Options{}, []string{
+			"Map(63) = (8, 0) which is outside the 8x8 space",
+		}},
+		{outside{h}, Options{Continuous: true}, []string{
+			"Map(63) = (8, 0) which is outside the 8x8 space",
+			"Map(62) = (7, 1) and Map(63) = (8, 0) are not next to each other",
+		}},
+		{wrongError{h}, Options{}, []string{
+			"MapInverse(-1, 0) err = bad coordinates",
+			"MapInverse(0, -1) err = bad coordinates",
+			"MapInverse(8, 0) err = bad coordinates",
+			"MapInverse(0, 8) err = bad coordinates",
+			"MapInverse(8, 8) err = bad coordinates",
+		}},
+		{badBatch{h}, Options{MaxCells: 3}, []string{
+			"MapBatch gave 0 -> (1, 0) want (0, 0)",
+			"MapBatch gave 63 -> (8, 0) want (7, 0)",
+			"MapBatch gave ",
+			"MapInverseBatch returned error: value is out of range",
+		}},
+		{empty{h}, Options{}, []string{
+			"GetDimensions() = 0, 8 want positive dimensions",
+		}},
+		{m, Options{Continuous: true}, []string{
+			"Map(1) = (0, 1) and Map(2) = (1, 0) are not next to each other",
+		}},
+	}
+
+	for _, tc := range testCases {
+		r := &recorder{}
+		Verify(r, tc.curve, tc.opts)
+
+		if tc.curve == m {
+			// Only check the first of Morton's many jumps.
+			r.errors = r.errors[:1]
+		}
+		if len(r.errors) != len(tc.want) {
+			t.Errorf("Verify(%T) reported %d errors want %d:\n%s", tc.curve, len(r.errors), len(tc.want), strings.Join(r.errors, "\n"))
+			continue
+		}
+		for i, want := range tc.want {
+			if !strings.Contains(r.errors[i], want) {
+				t.Errorf("Verify(%T) error %d = %q want it to contain %q", tc.curve, i, r.errors[i], want)
+			}
+		}
+	}
+}
+
+func TestVerifyMaxErrors(t *testing.T) {
+	h, _ := hilbert.NewHilbert(16)
+	r := &recorder{}
+	Verify(r, broken{h}, Options{Continuous: true})
+
+	// Both the bijection and continuity checks give up after maxErrors, and say so.
+	if want := 2 * (maxErrors + 1); len(r.errors) != want {
+		t.Fatalf("Verify(broken) reported %d errors want %d:\n%s", len(r.errors), want, strings.Join(r.errors, "\n"))
+	}
+	for _, i := range []int{maxErrors, 2*maxErrors + 1} {
+		if !strings.Contains(r.errors[i], "too many errors") {
+			t.Errorf("Verify(broken) error %d = %q want too many errors", i, r.errors[i])
+		}
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	if got := indexes(5, 10, 1); fmt.Sprint(got) != "[0 1 2 3 4]" {
+		t.Errorf("indexes(5, 10) = %v want [0 1 2 3 4]", got)
+	}
+
+	got := indexes(1000, 10, 1)
+	if len(got) != 10 || got[0] != 0 || got[1] != 999 {
+		t.Errorf("indexes(1000, 10) = %v want 10 indexes starting with 0 and 999", got)
+	}
+	for _, i := range got {
+		if i < 0 || i >= 1000 {
+			t.Errorf("indexes(1000, 10) = %v want indexes within [0, 1000)", got)
+		}
+	}
+}
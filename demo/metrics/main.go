@@ -0,0 +1,70 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command metrics prints a table comparing the locality of the curves in the hilbert package,
+// at several sizes.
+//
+//	go run demo/metrics/main.go -queries 1000
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/google/hilbert"
+	"github.com/google/hilbert/metrics"
+)
+
+var (
+	queries = flag.Int("queries", 1000, "number of random box queries for each curve")
+	seed    = flag.Int64("seed", 1, "seed for the positions of the queries")
+)
+
+func main() {
+	flag.Parse()
+
+	var curves []hilbert.SpaceFilling
+	add := func(curve hilbert.SpaceFilling, err error) {
+		if err != nil {
+			log.Fatalf("creating curve failed: %s", err)
+		}
+		curves = append(curves, curve)
+	}
+	for _, n := range []int{16, 32, 64} {
+		add(hilbert.NewHilbert(n))
+		add(hilbert.NewMoore(n))
+		add(hilbert.NewMorton(n))
+	}
+	for _, n := range []int{9, 27, 81} {
+		add(hilbert.NewPeano(n))
+	}
+	add(hilbert.NewHilbertRect(48, 32))
+
+	var reports []*metrics.Report
+	for _, curve := range curves {
+		r, err := metrics.Measure(curve, metrics.Options{
+			Queries: *queries,
+			Seed:    *seed,
+		})
+		if err != nil {
+			log.Fatalf("measuring %T failed: %s", curve, err)
+		}
+		reports = append(reports, r)
+	}
+
+	if err := metrics.WriteTable(os.Stdout, reports); err != nil {
+		log.Fatalf("writing table failed: %s", err)
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package geo is for indexing geographic points with cell IDs along a Hilbert curve, in the
+// spirit of S2 cell IDs.
+//
+// CellID uses a single planar projection. Latitude and longitude are projected onto a 2^30 by
+// 2^30 grid with an equirectangular projection, where longitude runs along x and latitude
+// along y. Each cell of the grid, and each aligned square of cells, has a 64 bit CellID. Cells
+// at level k are a 2^k by 2^k grid covering the whole world, and each is split into four
+// children at level k+1.
+//
+// SphereCellID instead projects the sphere onto the six faces of a cube, which distorts far
+// less near the poles. Each face is split into cells in the same way.
+//
+// A CellID is a hilbert.Cell, limited to MaxLevel: the cell's position along the Hilbert curve
+// at its level, followed by a single 1 bit and then zeros, as in S2. The position along the
+// Hilbert curve of a cell's children starts with the position of the cell, so the IDs of all
+// the descendants of a cell are within a contiguous range, and sorting cells by ID sorts them
+// along the curve.
+package geo
+
+import (
+	"math"
+
+	"github.com/google/hilbert"
+)
+
+// MaxLevel is the level of the smallest cells, which are about 4cm high.
+const MaxLevel = 30
+
+// gridSize is the width and height of the grid at MaxLevel.
+const gridSize = 1 << MaxLevel
+
+// curve maps between the grid and positions at MaxLevel.
+var curve = func() *hilbert.Hilbert64 {
+	s, err := hilbert.NewHilbert64(gridSize)
+	if err != nil {
+		panic("assertion failure: " + err.Error())
+	}
+	return s
+}()
+
+// CellID identifies a cell in the hierarchy. It has the same encoding as hilbert.Cell, so it
+// can be converted to one to use with a hilbert.Hilbert space of width 2^30.
+type CellID uint64
+
+// LatLng is a point in degrees.
+type LatLng struct {
+	Lat, Lng float64
+}
+
+// Rect is a rectangle of latitude and longitude, in degrees. It does not cross the
+// antimeridian.
+type Rect struct {
+	Lo, Hi LatLng
+}
+
+// Contains returns true if p is within the rectangle.
+func (r Rect) Contains(p LatLng) bool {
+	return p.Lat >= r.Lo.Lat && p.Lat <= r.Hi.Lat && p.Lng >= r.Lo.Lng && p.Lng <= r.Hi.Lng
+}
+
+// Intersects returns true if the rectangles share any point.
+func (r Rect) Intersects(o Rect) bool {
+	return r.Lo.Lat <= o.Hi.Lat && o.Lo.Lat <= r.Hi.Lat && r.Lo.Lng <= o.Hi.Lng && o.Lo.Lng <= r.Hi.Lng
+}
+
+// ContainsRect returns true if o is within the rectangle.
+func (r Rect) ContainsRect(o Rect) bool {
+	return r.Contains(o.Lo) && r.Contains(o.Hi)
+}
+
+// CellIDFromLatLng returns the leaf cell containing p. Latitudes are clamped to [-90,90], and
+// longitudes are wrapped into [-180,180).
+func CellIDFromLatLng(p LatLng) CellID {
+	return cellIDFromGrid(gridX(p.Lng), gridY(p.Lat), MaxLevel)
+}
+
+// gridX returns the column of the grid containing the longitude lng.
+func gridX(lng float64) uint32 {
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return clampGrid(lng / 360 * gridSize)
+}
+
+// gridY returns the row of the grid containing the latitude lat.
+func gridY(lat float64) uint32 {
+	return clampGrid((lat + 90) / 180 * gridSize)
+}
+
+func clampGrid(f float64) uint32 {
+	// Written so NaNs end up in the first cell.
+	if !(f >= 0) {
+		return 0
+	}
+	if f >= gridSize {
+		return gridSize - 1
+	}
+	return uint32(f)
+}
+
+// cellIDFromGrid returns the cell at level which contains the leaf cell (x,y).
+func cellIDFromGrid(x, y uint32, level int) CellID {
+	pos, err := curve.MapInverse(x, y)
+	if err != nil {
+		panic("assertion failure: " + err.Error())
+	}
+	return CellIDFromPos(pos>>uint(2*(MaxLevel-level)), level)
+}
+
+// CellIDFromPos returns the cell from its position along the Hilbert curve at level, which
+// must be in the range [0, 4^level-1]. If either is out of range the result is not valid.
+func CellIDFromPos(pos uint64, level int) CellID {
+	if level > MaxLevel {
+		return 0
+	}
+	c, err := hilbert.NewCell(level, pos)
+	if err != nil {
+		return 0
+	}
+	return CellID(c)
+}
+
+// cell returns c as a hilbert.Cell.
+func (c CellID) cell() hilbert.Cell {
+	return hilbert.Cell(c)
+}
+
+// lsb returns the lowest set bit of the ID, which marks the end of the position.
+func (c CellID) lsb() uint64 {
+	return uint64(c) & -uint64(c)
+}
+
+// lsbForLevel returns the lowest set bit of IDs at level.
+func lsbForLevel(level int) uint64 {
+	return 1 << uint(2*(hilbert.MaxCellLevel-level))
+}
+
+// IsValid returns true if c is a valid cell ID.
+func (c CellID) IsValid() bool {
+	return c.cell().IsValid() && c.lsb() >= lsbForLevel(MaxLevel)
+}
+
+// Level returns the level of the cell, from 0 for the whole world to MaxLevel for leaf cells.
+func (c CellID) Level() int {
+	return c.cell().Level()
+}
+
+// Pos returns the cell's position along the Hilbert curve at its level.
+func (c CellID) Pos() uint64 {
+	return c.cell().Index()
+}
+
+// IsLeaf returns true if c is a cell at MaxLevel.
+func (c CellID) IsLeaf() bool {
+	return c.lsb() == lsbForLevel(MaxLevel)
+}
+
+// Parent returns the cell at level containing c. level must be no greater than c's level.
+func (c CellID) Parent(level int) CellID {
+	return CellID(c.cell().Ancestor(level))
+}
+
+// Children returns the four cells within c, at the next level, in order along the curve.
+// c must not be a leaf.
+func (c CellID) Children() [4]CellID {
+	var children [4]CellID
+	for i, child := range c.cell().Children() {
+		children[i] = CellID(child)
+	}
+	return children
+}
+
+// RangeMin returns the smallest leaf cell ID within c.
+func (c CellID) RangeMin() CellID {
+	return CellID(uint64(c) - c.lsb() + lsbForLevel(MaxLevel))
+}
+
+// RangeMax returns the largest leaf cell ID within c.
+func (c CellID) RangeMax() CellID {
+	return CellID(uint64(c) + c.lsb() - lsbForLevel(MaxLevel))
+}
+
+// Contains returns true if o is c, or a descendant of it.
+func (c CellID) Contains(o CellID) bool {
+	return c.cell().Contains(o.cell())
+}
+
+// grid returns the column and row of the cell at its level.
+func (c CellID) grid() (i, j uint32) {
+	x, y, err := curve.Map(c.RangeMin().Pos())
+	if err != nil {
+		panic("assertion failure: " + err.Error())
+	}
+	shift := uint(MaxLevel - c.Level())
+	return x >> shift, y >> shift
+}
+
+// cellIDFromCell returns the cell at level in column i and row j of the grid at that level.
+func cellIDFromCell(i, j uint32, level int) CellID {
+	shift := uint(MaxLevel - level)
+	return cellIDFromGrid(i<<shift, j<<shift, level)
+}
+
+// Bounds returns the rectangle covered by the cell.
+func (c CellID) Bounds() Rect {
+	level := c.Level()
+	i, j := c.grid()
+	n := float64(uint64(1) << uint(level))
+	return Rect{
+		Lo: LatLng{Lat: float64(j)/n*180 - 90, Lng: float64(i)/n*360 - 180},
+		Hi: LatLng{Lat: float64(j+1)/n*180 - 90, Lng: float64(i+1)/n*360 - 180},
+	}
+}
+
+// Center returns the centre of the cell.
+func (c CellID) Center() LatLng {
+	b := c.Bounds()
+	return LatLng{Lat: (b.Lo.Lat + b.Hi.Lat) / 2, Lng: (b.Lo.Lng + b.Hi.Lng) / 2}
+}
+
+// Neighbors returns the cells, at the same level, which share an edge with c. Longitude wraps
+// around the antimeridian, but latitude does not, so cells touching the poles only have three
+// neighbors, and the level 0 cell has none.
+func (c CellID) Neighbors() []CellID {
+	level := c.Level()
+	if level == 0 {
+		return nil
+	}
+
+	n := uint32(1) << uint(level)
+	i, j := c.grid()
+
+	neighbors := []CellID{
+		cellIDFromCell((i+n-1)%n, j, level),
+		cellIDFromCell((i+1)%n, j, level),
+	}
+	if j > 0 {
+		neighbors = append(neighbors, cellIDFromCell(i, j-1, level))
+	}
+	if j < n-1 {
+		neighbors = append(neighbors, cellIDFromCell(i, j+1, level))
+	}
+
+	// At level 1 the cells to the east and west are the same.
+	if neighbors[0] == neighbors[1] {
+		neighbors = neighbors[1:]
+	}
+	return neighbors
+}
+
+// Covering returns the cells which together cover the rectangle, in increasing order. Cells
+// wholly within the rectangle are returned as large as possible, and the cells on its edges
+// are at maxLevel, which is clamped to MaxLevel. Cells are half-open, so those which only
+// touch the high edges of the rectangle are not included.
+func Covering(r Rect, maxLevel int) []CellID {
+	if maxLevel > MaxLevel {
+		maxLevel = MaxLevel
+	}
+
+	var cells []CellID
+	var visit func(c CellID)
+	visit = func(c CellID) {
+		b := c.Bounds()
+		if !r.intersectsCell(b) {
+			return
+		}
+		if r.ContainsRect(b) || c.Level() >= maxLevel {
+			cells = append(cells, c)
+			return
+		}
+		for _, child := range c.Children() {
+			visit(child)
+		}
+	}
+	visit(CellIDFromPos(0, 0))
+	return cells
+}
+
+// intersectsCell returns true if the rectangle shares any point with the cell bounds b. The
+// cell includes its low edges but not its high edges, except at the north pole and longitude
+// 180, so each point is in one cell. A rectangle which is a single point, along either axis, intersects
+// the cell containing it, and otherwise the rectangle must overlap the cell by more than an
+// edge.
+func (r Rect) intersectsCell(b Rect) bool {
+	return spanIntersectsCell(r.Lo.Lat, r.Hi.Lat, b.Lo.Lat, b.Hi.Lat, 90) &&
+		spanIntersectsCell(r.Lo.Lng, r.Hi.Lng, b.Lo.Lng, b.Hi.Lng, 180)
+}
+
+// spanIntersectsCell is intersectsCell along one axis, where max is the end of the axis.
+func spanIntersectsCell(lo, hi, cellLo, cellHi, max float64) bool {
+	if lo == hi {
+		return cellLo <= lo && (lo < cellHi || cellHi == max)
+	}
+	return lo < cellHi && cellLo < hi
+}
@@ -0,0 +1,304 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/google/hilbert"
+)
+
+func randomLatLng(r *rand.Rand) LatLng {
+	return LatLng{Lat: r.Float64()*180 - 90, Lng: r.Float64()*360 - 180}
+}
+
+func TestCellIDFromLatLng(t *testing.T) {
+	var testCases = []struct {
+		p     LatLng
+		level int
+		want  uint64 // Position at level
+	}{
+		{LatLng{-90, -180}, 1, 0},
+		{LatLng{45, -90}, 1, 1},
+		{LatLng{45, 90}, 1, 2},
+		{LatLng{-45, 90}, 1, 3},
+		{LatLng{90, 180}, 1, 1}, // Longitude wraps, latitude is clamped
+		{LatLng{90, 179.9}, 1, 2},
+		{LatLng{0, 0}, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		c := CellIDFromLatLng(tc.p).Parent(tc.level)
+		if got := c.Pos(); got != tc.want {
+			t.Errorf("CellIDFromLatLng(%v).Parent(%d).Pos() = %d want %d", tc.p, tc.level, got, tc.want)
+		}
+		if got := c.Level(); got != tc.level {
+			t.Errorf("CellIDFromLatLng(%v).Parent(%d).Level() = %d want %d", tc.p, tc.level, got, tc.level)
+		}
+	}
+}
+
+// TestCellIDMatchesHilbert checks cell positions at each level match Hilbert.MapInverse.
+func TestCellIDMatchesHilbert(t *testing.T) {
+	for level := 0; level <= 6; level++ {
+		n := 1 << uint(level)
+		s, err := hilbert.NewHilbert(n)
+		if err != nil {
+			t.Fatalf("NewHilbert(%d) failed: %s", n, err)
+		}
+
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				c := cellIDFromCell(uint32(i), uint32(j), level)
+				want, _ := s.MapInverse(i, j)
+				if got := c.Pos(); got != uint64(want) {
+					t.Errorf("level %d: cell (%d,%d).Pos() = %d want %d", level, i, j, got, want)
+				}
+				if c != CellIDFromPos(uint64(want), level) {
+					t.Errorf("level %d: cell (%d,%d) = %x want CellIDFromPos(%d) = %x", level, i, j, c, want, CellIDFromPos(uint64(want), level))
+				}
+
+				// A CellID is the same as a hilbert.Cell.
+				cell, err := s.CellFromPoint(i, j, level)
+				if err != nil {
+					t.Fatalf("level %d: CellFromPoint(%d, %d) failed: %s", level, i, j, err)
+				}
+				if c != CellID(cell) {
+					t.Errorf("level %d: cell (%d,%d) = %x want hilbert.Cell %x", level, i, j, c, cell)
+				}
+			}
+		}
+	}
+
+	// hilbert.Cell goes one level deeper than CellID.
+	deepest, _ := hilbert.NewCell(MaxLevel+1, 0)
+	if c := CellID(deepest); c.IsValid() {
+		t.Errorf("CellID(%x).IsValid() = true want false", c)
+	}
+	if c := CellIDFromPos(0, MaxLevel+1); c.IsValid() {
+		t.Errorf("CellIDFromPos(0, %d) = %x is valid", MaxLevel+1, c)
+	}
+}
+
+func TestCellIDHierarchy(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		p := randomLatLng(r)
+		leaf := CellIDFromLatLng(p)
+		if !leaf.IsValid() || !leaf.IsLeaf() || leaf.Level() != MaxLevel {
+			t.Fatalf("CellIDFromLatLng(%v) = %x is not a valid leaf", p, leaf)
+		}
+
+		for level := 0; level <= MaxLevel; level++ {
+			c := leaf.Parent(level)
+			if !c.IsValid() || c.Level() != level {
+				t.Fatalf("%x.Parent(%d) = %x not valid at that level", leaf, level, c)
+			}
+			if !c.Contains(leaf) {
+				t.Errorf("%x.Contains(%x) = false want true", c, leaf)
+			}
+			if !c.Bounds().Contains(p) {
+				t.Errorf("%x.Bounds() = %v does not contain %v", c, c.Bounds(), p)
+			}
+			if level == MaxLevel {
+				continue
+			}
+
+			// Exactly one child contains the point, and each child's parent is c.
+			found := 0
+			for _, child := range c.Children() {
+				if child.Parent(level) != c || child.Level() != level+1 {
+					t.Errorf("%x child %x has wrong parent or level", c, child)
+				}
+				if child.Contains(leaf) {
+					found++
+				}
+			}
+			if found != 1 {
+				t.Errorf("%x has %d children containing %x want 1", c, found, leaf)
+			}
+		}
+	}
+}
+
+// TestCellIDChildren checks the children tile the parent in curve order.
+func TestCellIDChildren(t *testing.T) {
+	for _, c := range []CellID{CellIDFromPos(0, 0), CellIDFromPos(2, 1), CellIDFromPos(37, 4)} {
+		b := c.Bounds()
+		children := c.Children()
+		prev := children[3].Bounds()
+		for i, child := range children {
+			cb := child.Bounds()
+			if !b.ContainsRect(cb) {
+				t.Errorf("%x.Bounds() = %v does not contain child %d %v", c, b, i, cb)
+			}
+			if child.Pos() != c.Pos()*4+uint64(i) {
+				t.Errorf("%x child %d Pos() = %d want %d", c, i, child.Pos(), c.Pos()*4+uint64(i))
+			}
+			if i > 0 && !sharesEdge(prev, cb) {
+				t.Errorf("%x children %d and %d do not share an edge", c, i-1, i)
+			}
+			prev = cb
+		}
+		if children[0].RangeMin() != c.RangeMin() || children[3].RangeMax() != c.RangeMax() {
+			t.Errorf("%x children do not span the same range as the parent", c)
+		}
+	}
+}
+
+// sharesEdge returns true if the rectangles touch along an edge.
+func sharesEdge(a, b Rect) bool {
+	lat := a.Lo.Lat < b.Hi.Lat && b.Lo.Lat < a.Hi.Lat
+	lng := a.Lo.Lng < b.Hi.Lng && b.Lo.Lng < a.Hi.Lng
+	return (lat && (a.Hi.Lng == b.Lo.Lng || b.Hi.Lng == a.Lo.Lng)) ||
+		(lng && (a.Hi.Lat == b.Lo.Lat || b.Hi.Lat == a.Lo.Lat))
+}
+
+func TestCellIDNeighbors(t *testing.T) {
+	var testCases = []struct {
+		c    CellID
+		want int
+	}{
+		{CellIDFromPos(0, 0), 0},
+		{CellIDFromPos(0, 1), 2},
+		{cellIDFromCell(2, 0, 2), 3},  // On the south pole
+		{cellIDFromCell(1, 3, 2), 3},  // On the north pole
+		{cellIDFromCell(2, 1, 2), 4},  // Middle
+		{cellIDFromCell(0, 0, 2), 3},  // Antimeridian and pole
+		{cellIDFromCell(15, 5, 4), 4}, // Antimeridian
+	}
+
+	for _, tc := range testCases {
+		neighbors := tc.c.Neighbors()
+		if len(neighbors) != tc.want {
+			t.Errorf("%x.Neighbors() = %x want %d cells", tc.c, neighbors, tc.want)
+		}
+		for _, n := range neighbors {
+			if n.Level() != tc.c.Level() {
+				t.Errorf("%x neighbor %x is at level %d", tc.c, n, n.Level())
+			}
+			b, nb := tc.c.Bounds(), n.Bounds()
+			wrapped := nb
+			if nb.Lo.Lng == -180 && b.Hi.Lng == 180 {
+				wrapped.Lo.Lng, wrapped.Hi.Lng = nb.Lo.Lng+360, nb.Hi.Lng+360
+			} else if nb.Hi.Lng == 180 && b.Lo.Lng == -180 {
+				wrapped.Lo.Lng, wrapped.Hi.Lng = nb.Lo.Lng-360, nb.Hi.Lng-360
+			}
+			if !sharesEdge(b, nb) && !sharesEdge(b, wrapped) {
+				t.Errorf("%x neighbor %x does not share an edge: %v %v", tc.c, n, b, nb)
+			}
+		}
+	}
+}
+
+func TestCovering(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		a, b := randomLatLng(r), randomLatLng(r)
+		rect := Rect{
+			Lo: LatLng{Lat: minFloat(a.Lat, b.Lat), Lng: minFloat(a.Lng, b.Lng)},
+			Hi: LatLng{Lat: maxFloat(a.Lat, b.Lat), Lng: maxFloat(a.Lng, b.Lng)},
+		}
+		level := 1 + r.Intn(6)
+		cells := Covering(rect, level)
+
+		if !sort.SliceIsSorted(cells, func(i, j int) bool { return cells[i] < cells[j] }) {
+			t.Errorf("Covering(%v, %d) is not sorted", rect, level)
+		}
+		for j := 1; j < len(cells); j++ {
+			if cells[j-1].RangeMax() >= cells[j].RangeMin() {
+				t.Errorf("Covering(%v, %d) cells %x and %x overlap", rect, level, cells[j-1], cells[j])
+			}
+		}
+		for _, c := range cells {
+			if c.Level() > level {
+				t.Errorf("Covering(%v, %d) contains %x at level %d", rect, level, c, c.Level())
+			}
+			if !rect.intersectsCell(c.Bounds()) {
+				t.Errorf("Covering(%v, %d) contains %x which does not intersect", rect, level, c)
+			}
+		}
+
+		// Every point in the rectangle is in a cell.
+		for j := 0; j < 100; j++ {
+			p := LatLng{
+				Lat: rect.Lo.Lat + r.Float64()*(rect.Hi.Lat-rect.Lo.Lat),
+				Lng: rect.Lo.Lng + r.Float64()*(rect.Hi.Lng-rect.Lo.Lng),
+			}
+			leaf := CellIDFromLatLng(p)
+			k := sort.Search(len(cells), func(k int) bool { return cells[k].RangeMax() >= leaf })
+			if k == len(cells) || !cells[k].Contains(leaf) {
+				t.Errorf("Covering(%v, %d) does not contain %v", rect, level, p)
+			}
+		}
+	}
+}
+
+// TestCoveringEdges checks cells which only touch the edges of the rectangle are not included.
+func TestCoveringEdges(t *testing.T) {
+	var testCases = []struct {
+		rect     Rect
+		maxLevel int
+		want     []CellID
+	}{
+		// One level 2 cell, which touches eight others.
+		{Rect{LatLng{0, 0}, LatLng{45, 90}}, 2, []CellID{CellIDFromLatLng(LatLng{1, 1}).Parent(2)}},
+		{Rect{LatLng{0, 0}, LatLng{45, 90}}, 10, []CellID{CellIDFromLatLng(LatLng{1, 1}).Parent(2)}},
+		// A corner of four cells is only within the one above and to the right.
+		{Rect{LatLng{45, 90}, LatLng{45, 90}}, 3, []CellID{CellIDFromLatLng(LatLng{45, 90}).Parent(3)}},
+		// The north pole is within the last row.
+		{Rect{LatLng{90, 0}, LatLng{90, 0}}, 1, []CellID{CellIDFromLatLng(LatLng{90, 0}).Parent(1)}},
+		{Rect{LatLng{-90, -180}, LatLng{90, 180}}, 5, []CellID{CellIDFromPos(0, 0)}},
+		// Levels beyond MaxLevel are clamped to it.
+		{Rect{LatLng{10, 20}, LatLng{10, 20}}, MaxLevel + 10, []CellID{CellIDFromLatLng(LatLng{10, 20})}},
+	}
+
+	for _, tc := range testCases {
+		if got := Covering(tc.rect, tc.maxLevel); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Covering(%v, %d) = %x want %x", tc.rect, tc.maxLevel, got, tc.want)
+		}
+	}
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func BenchmarkCellIDFromLatLng(b *testing.B) {
+	p := LatLng{Lat: 51.5, Lng: -0.12}
+	for i := 0; i < b.N; i++ {
+		CellIDFromLatLng(p)
+	}
+}
+
+func BenchmarkCovering(b *testing.B) {
+	rect := Rect{Lo: LatLng{Lat: 10, Lng: 20}, Hi: LatLng{Lat: 30, Lng: 60}}
+	for i := 0; i < b.N; i++ {
+		Covering(rect, 10)
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"math"
+
+	"github.com/google/hilbert"
+)
+
+// NumFaces is the number of faces of the cube the sphere is projected onto.
+const NumFaces = 6
+
+// posBits is the number of bits below the face in a SphereCellID.
+const posBits = 2*MaxLevel + 1
+
+// cellShift is the shift from a CellID to the bits below the face in a SphereCellID. A CellID
+// has room for the deeper levels of a hilbert.Cell, which SphereCellID drops to fit the face.
+const cellShift = 2 * (hilbert.MaxCellLevel - MaxLevel)
+
+// Point is a point on the unit sphere, with z through the north pole and x through latitude
+// and longitude zero.
+type Point struct {
+	X, Y, Z float64
+}
+
+// PointFromLatLng returns the unit vector for p.
+func PointFromLatLng(p LatLng) Point {
+	lat := p.Lat * math.Pi / 180
+	lng := p.Lng * math.Pi / 180
+	return Point{
+		X: math.Cos(lat) * math.Cos(lng),
+		Y: math.Cos(lat) * math.Sin(lng),
+		Z: math.Sin(lat),
+	}
+}
+
+// LatLngFromPoint returns the latitude and longitude of p, which need not be unit length.
+func LatLngFromPoint(p Point) LatLng {
+	return LatLng{
+		Lat: math.Atan2(p.Z, math.Hypot(p.X, p.Y)) * 180 / math.Pi,
+		Lng: math.Atan2(p.Y, p.X) * 180 / math.Pi,
+	}
+}
+
+// normalize returns p scaled to unit length.
+func (p Point) normalize() Point {
+	n := math.Sqrt(p.X*p.X + p.Y*p.Y + p.Z*p.Z)
+	return Point{p.X / n, p.Y / n, p.Z / n}
+}
+
+// SphereCellID identifies a cell in the hierarchy on the cube projection of the sphere.
+//
+// The faces are numbered as in S2: 0 to 2 are the faces pierced by the positive x, y and z
+// axes, and 3 to 5 by the negative axes. The Hilbert curve on the odd faces has its x and y
+// swapped, so that the end of each face's curve is next to the start of the next face's, and
+// the end of face 5 next to the start of face 0. The face is stored in the top three bits of
+// the ID, above the position along the curve, so the order of the IDs is continuous over the
+// whole sphere. Below the face, the ID is a CellID shifted down by two bits, so the face fits.
+type SphereCellID uint64
+
+// SphereCellIDFromPoint returns the leaf cell containing p, which need not be unit length.
+func SphereCellIDFromPoint(p Point) SphereCellID {
+	face, u, v := xyzToFaceUV(p)
+	i := clampGrid(uvToST(u) * gridSize)
+	j := clampGrid(uvToST(v) * gridSize)
+	return sphereCellIDFromFaceIJ(face, i, j, MaxLevel)
+}
+
+// SphereCellIDFromLatLng returns the leaf cell containing p.
+func SphereCellIDFromLatLng(p LatLng) SphereCellID {
+	return SphereCellIDFromPoint(PointFromLatLng(p))
+}
+
+// SphereCellIDFromFacePos returns the cell on face from its position along the Hilbert curve
+// at level, which must be in the range [0, 4^level-1].
+func SphereCellIDFromFacePos(face int, pos uint64, level int) SphereCellID {
+	return sphereCellID(face, CellIDFromPos(pos, level))
+}
+
+// sphereCellID returns the cell c on face.
+func sphereCellID(face int, c CellID) SphereCellID {
+	return SphereCellID(uint64(face)<<posBits | uint64(c)>>cellShift)
+}
+
+// sphereCellIDFromFaceIJ returns the cell at level which contains the leaf cell (i,j) of face.
+func sphereCellIDFromFaceIJ(face int, i, j uint32, level int) SphereCellID {
+	if face&1 == 1 {
+		i, j = j, i
+	}
+	return sphereCellID(face, cellIDFromGrid(i, j, level))
+}
+
+// cellID returns the part of the ID below the face, as a CellID.
+func (c SphereCellID) cellID() CellID {
+	return CellID(c&(1<<posBits-1)) << cellShift
+}
+
+// lsb returns the lowest set bit of the ID, which marks the end of the position.
+func (c SphereCellID) lsb() uint64 {
+	return uint64(c) & -uint64(c)
+}
+
+// IsValid returns true if c is a valid cell ID.
+func (c SphereCellID) IsValid() bool {
+	return c.Face() < NumFaces && c.cellID().IsValid()
+}
+
+// Face returns the face of the cube the cell is on.
+func (c SphereCellID) Face() int {
+	return int(c >> posBits)
+}
+
+// Level returns the level of the cell, from 0 for a whole face to MaxLevel for leaf cells.
+func (c SphereCellID) Level() int {
+	return c.cellID().Level()
+}
+
+// Pos returns the cell's position along the Hilbert curve of its face, at its level.
+func (c SphereCellID) Pos() uint64 {
+	return c.cellID().Pos()
+}
+
+// IsLeaf returns true if c is a cell at MaxLevel.
+func (c SphereCellID) IsLeaf() bool {
+	return c&1 != 0
+}
+
+// Parent returns the cell at level containing c. level must be no greater than c's level.
+func (c SphereCellID) Parent(level int) SphereCellID {
+	lsb := lsbForLevel(level) >> cellShift
+	return SphereCellID((uint64(c) & -lsb) | lsb)
+}
+
+// Children returns the four cells within c, at the next level, in order along the curve.
+// c must not be a leaf.
+func (c SphereCellID) Children() [4]SphereCellID {
+	var children [4]SphereCellID
+	for i, child := range c.cellID().Children() {
+		children[i] = sphereCellID(c.Face(), child)
+	}
+	return children
+}
+
+// RangeMin returns the smallest leaf cell ID within c.
+func (c SphereCellID) RangeMin() SphereCellID {
+	return SphereCellID(uint64(c) - (c.lsb() - 1))
+}
+
+// RangeMax returns the largest leaf cell ID within c.
+func (c SphereCellID) RangeMax() SphereCellID {
+	return SphereCellID(uint64(c) + (c.lsb() - 1))
+}
+
+// Contains returns true if o is c, or a descendant of it.
+func (c SphereCellID) Contains(o SphereCellID) bool {
+	return o >= c.RangeMin() && o <= c.RangeMax()
+}
+
+// Next returns the next cell at the same level along the curve, moving on to the next face at
+// the end of each face. The cell after the last cell of face 5 is not valid.
+func (c SphereCellID) Next() SphereCellID {
+	return c + SphereCellID(c.lsb()<<1)
+}
+
+// Prev returns the previous cell at the same level along the curve. The cell before the first
+// cell of face 0 is not valid.
+func (c SphereCellID) Prev() SphereCellID {
+	return c - SphereCellID(c.lsb()<<1)
+}
+
+// faceIJ returns the face, and the column and row of the leaf cell at the cell's bottom left
+// corner on the face.
+func (c SphereCellID) faceIJ() (face int, i, j uint32) {
+	i, j, err := curve.Map(c.cellID().RangeMin().Pos())
+	if err != nil {
+		panic("assertion failure: " + err.Error())
+	}
+
+	// The curve enters the cell at whichever corner its parent dictates.
+	mask := ^uint32(0) << uint(MaxLevel-c.Level())
+	i, j = i&mask, j&mask
+
+	face = c.Face()
+	if face&1 == 1 {
+		i, j = j, i
+	}
+	return face, i, j
+}
+
+// pointFromFaceIJ returns the point on face at the leaf grid coordinates (i,j), which may be
+// fractional.
+func pointFromFaceIJ(face int, i, j float64) Point {
+	return faceUVToXYZ(face, stToUV(i/gridSize), stToUV(j/gridSize)).normalize()
+}
+
+// Point returns the centre of the cell.
+func (c SphereCellID) Point() Point {
+	face, i, j := c.faceIJ()
+	half := float64(uint64(1)<<uint(MaxLevel-c.Level())) / 2
+	return pointFromFaceIJ(face, float64(i)+half, float64(j)+half)
+}
+
+// LatLng returns the centre of the cell.
+func (c SphereCellID) LatLng() LatLng {
+	return LatLngFromPoint(c.Point())
+}
+
+// Vertices returns the corners of the cell, anticlockwise from the bottom left of its face.
+func (c SphereCellID) Vertices() [4]Point {
+	face, i, j := c.faceIJ()
+	size := float64(uint64(1) << uint(MaxLevel-c.Level()))
+	i0, j0 := float64(i), float64(j)
+	return [4]Point{
+		pointFromFaceIJ(face, i0, j0),
+		pointFromFaceIJ(face, i0+size, j0),
+		pointFromFaceIJ(face, i0+size, j0+size),
+		pointFromFaceIJ(face, i0, j0+size),
+	}
+}
+
+// xyzToFaceUV returns the face p projects onto, and its (u,v) coordinates on that face, each
+// within [-1,1].
+func xyzToFaceUV(p Point) (face int, u, v float64) {
+	ax, ay, az := math.Abs(p.X), math.Abs(p.Y), math.Abs(p.Z)
+	switch {
+	case ax >= ay && ax >= az:
+		face = 0
+		if p.X < 0 {
+			face = 3
+		}
+	case ay >= az:
+		face = 1
+		if p.Y < 0 {
+			face = 4
+		}
+	default:
+		face = 2
+		if p.Z < 0 {
+			face = 5
+		}
+	}
+
+	switch face {
+	case 0:
+		u, v = p.Y/p.X, p.Z/p.X
+	case 1:
+		u, v = -p.X/p.Y, p.Z/p.Y
+	case 2:
+		u, v = -p.X/p.Z, -p.Y/p.Z
+	case 3:
+		u, v = p.Z/p.X, p.Y/p.X
+	case 4:
+		u, v = p.Z/p.Y, -p.X/p.Y
+	default:
+		u, v = -p.Y/p.Z, -p.X/p.Z
+	}
+	return face, u, v
+}
+
+// faceUVToXYZ returns the point on the cube at (u,v) on face. It is not unit length.
+func faceUVToXYZ(face int, u, v float64) Point {
+	switch face {
+	case 0:
+		return Point{1, u, v}
+	case 1:
+		return Point{-u, 1, v}
+	case 2:
+		return Point{-u, -v, 1}
+	case 3:
+		return Point{-1, -v, -u}
+	case 4:
+		return Point{v, -1, -u}
+	default:
+		return Point{v, u, -1}
+	}
+}
+
+// uvToST converts a coordinate on a face from [-1,1] to [0,1]. The quadratic transform, as used
+// by S2, makes the cells on a face closer to equal in area than a linear one would.
+func uvToST(u float64) float64 {
+	if u >= 0 {
+		return 0.5 * math.Sqrt(1+3*u)
+	}
+	return 1 - 0.5*math.Sqrt(1-3*u)
+}
+
+// stToUV is the inverse of uvToST.
+func stToUV(s float64) float64 {
+	if s >= 0.5 {
+		return (4*s*s - 1) / 3
+	}
+	return (1 - 4*(1-s)*(1-s)) / 3
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// closePoints returns true if the points are within about a millimetre on the Earth.
+func closePoints(a, b Point) bool {
+	return math.Abs(a.X-b.X) < 1e-10 && math.Abs(a.Y-b.Y) < 1e-10 && math.Abs(a.Z-b.Z) < 1e-10
+}
+
+func TestPointLatLng(t *testing.T) {
+	var testCases = []struct {
+		p    LatLng
+		want Point
+	}{
+		{LatLng{0, 0}, Point{1, 0, 0}},
+		{LatLng{0, 90}, Point{0, 1, 0}},
+		{LatLng{90, 0}, Point{0, 0, 1}},
+		{LatLng{0, 180}, Point{-1, 0, 0}},
+		{LatLng{0, -90}, Point{0, -1, 0}},
+		{LatLng{-90, 0}, Point{0, 0, -1}},
+	}
+
+	for _, tc := range testCases {
+		got := PointFromLatLng(tc.p)
+		if !closePoints(got, tc.want) {
+			t.Errorf("PointFromLatLng(%v) = %v want %v", tc.p, got, tc.want)
+		}
+		face := SphereCellIDFromLatLng(tc.p).Face()
+		if want := SphereCellIDFromPoint(tc.want).Face(); face != want {
+			t.Errorf("SphereCellIDFromLatLng(%v).Face() = %d want %d", tc.p, face, want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		p := LatLng{Lat: r.Float64()*178 - 89, Lng: r.Float64()*360 - 180}
+		got := LatLngFromPoint(PointFromLatLng(p))
+		if math.Abs(got.Lat-p.Lat) > 1e-9 || math.Abs(got.Lng-p.Lng) > 1e-9 {
+			t.Errorf("LatLngFromPoint(PointFromLatLng(%v)) = %v", p, got)
+		}
+	}
+}
+
+func TestFaceUV(t *testing.T) {
+	for face := 0; face < NumFaces; face++ {
+		for _, uv := range [][2]float64{{0, 0}, {0.5, -0.25}, {-0.9, 0.9}} {
+			p := faceUVToXYZ(face, uv[0], uv[1])
+			gotFace, u, v := xyzToFaceUV(p)
+			if gotFace != face || math.Abs(u-uv[0]) > 1e-15 || math.Abs(v-uv[1]) > 1e-15 {
+				t.Errorf("xyzToFaceUV(faceUVToXYZ(%d, %v)) = (%d, %v, %v)", face, uv, gotFace, u, v)
+			}
+		}
+	}
+
+	for _, s := range []float64{0, 0.1, 0.5, 0.75, 1} {
+		if got := uvToST(stToUV(s)); math.Abs(got-s) > 1e-15 {
+			t.Errorf("uvToST(stToUV(%v)) = %v", s, got)
+		}
+	}
+}
+
+func TestSphereCellIDRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		p := PointFromLatLng(randomLatLng(r))
+		leaf := SphereCellIDFromPoint(p)
+		if !leaf.IsValid() || !leaf.IsLeaf() {
+			t.Fatalf("SphereCellIDFromPoint(%v) = %x is not a valid leaf", p, leaf)
+		}
+
+		for _, level := range []int{0, 1, 5, 17, MaxLevel} {
+			c := leaf.Parent(level)
+			if !c.IsValid() || c.Level() != level || c.Face() != leaf.Face() || !c.Contains(leaf) {
+				t.Fatalf("%x.Parent(%d) = %x not valid", leaf, level, c)
+			}
+			if got := SphereCellIDFromPoint(c.Point()).Parent(level); got != c {
+				t.Errorf("SphereCellIDFromPoint(%x.Point()) = %x", c, got)
+			}
+			if got := SphereCellIDFromLatLng(c.LatLng()).Parent(level); got != c {
+				t.Errorf("SphereCellIDFromLatLng(%x.LatLng()) = %x", c, got)
+			}
+			if level < MaxLevel {
+				for k, child := range c.Children() {
+					if child.Parent(level) != c || child.Level() != level+1 {
+						t.Errorf("%x child %d = %x has wrong parent or level", c, k, child)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSphereCellIDFromFacePos(t *testing.T) {
+	for face := 0; face < NumFaces; face++ {
+		c := SphereCellIDFromFacePos(face, 9, 3)
+		if c.Face() != face || c.Pos() != 9 || c.Level() != 3 {
+			t.Errorf("SphereCellIDFromFacePos(%d, 9, 3) = %x has face %d pos %d level %d", face, c, c.Face(), c.Pos(), c.Level())
+		}
+	}
+	if c := SphereCellIDFromFacePos(NumFaces, 0, 0); c.IsValid() {
+		t.Errorf("SphereCellIDFromFacePos(%d, 0, 0).IsValid() = true want false", NumFaces)
+	}
+}
+
+// sharedVertices returns the number of vertices a and b have in common.
+func sharedVertices(a, b SphereCellID) int {
+	n := 0
+	for _, va := range a.Vertices() {
+		for _, vb := range b.Vertices() {
+			if closePoints(va, vb) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// TestSphereCellIDContinuous checks each cell shares an edge with the next, over the whole
+// sphere, including across the face seams and from the last face back to the first.
+func TestSphereCellIDContinuous(t *testing.T) {
+	for _, level := range []int{1, 2, 4} {
+		first := SphereCellIDFromFacePos(0, 0, level)
+		last := SphereCellIDFromFacePos(NumFaces-1, 1<<uint(2*level)-1, level)
+
+		count := 0
+		for c := first; c != last; c = c.Next() {
+			next := c.Next()
+			if !next.IsValid() || next.Prev() != c {
+				t.Fatalf("level %d: %x.Next() = %x not valid", level, c, next)
+			}
+			if n := sharedVertices(c, next); n != 2 {
+				t.Errorf("level %d: %x and %x share %d vertices want 2", level, c, next, n)
+			}
+			count++
+		}
+		if want := NumFaces<<uint(2*level) - 1; count != want {
+			t.Errorf("level %d: stepped %d cells want %d", level, count, want)
+		}
+		if n := sharedVertices(last, first); n != 2 {
+			t.Errorf("level %d: last %x and first %x share %d vertices want 2", level, last, first, n)
+		}
+		if last.Next().IsValid() {
+			t.Errorf("level %d: %x.Next() is valid", level, last)
+		}
+	}
+}
+
+// TestSphereCellIDSeams checks the leaf cells either side of each face seam are adjacent.
+func TestSphereCellIDSeams(t *testing.T) {
+	for face := 0; face < NumFaces; face++ {
+		end := SphereCellIDFromFacePos(face, 1<<(2*MaxLevel)-1, MaxLevel)
+		start := SphereCellIDFromFacePos((face+1)%NumFaces, 0, MaxLevel)
+		if face < NumFaces-1 && end.Next() != start {
+			t.Errorf("face %d: %x.Next() = %x want %x", face, end, end.Next(), start)
+		}
+
+		// Leaf cells are about 1e-9 radians across.
+		a, b := end.Point(), start.Point()
+		if d := math.Sqrt((a.X-b.X)*(a.X-b.X) + (a.Y-b.Y)*(a.Y-b.Y) + (a.Z-b.Z)*(a.Z-b.Z)); d > 2e-9 {
+			t.Errorf("face %d: end of face %v is %g from start of next face %v", face, a, d, b)
+		}
+
+		// Points just either side of the middle of the shared edge are in each cell.
+		var shared []Point
+		for _, va := range end.Vertices() {
+			for _, vb := range start.Vertices() {
+				if closePoints(va, vb) {
+					shared = append(shared, va)
+				}
+			}
+		}
+		if len(shared) != 2 {
+			t.Errorf("face %d: %x and %x share %d vertices want 2", face, end, start, len(shared))
+			continue
+		}
+		m := Point{(shared[0].X + shared[1].X) / 2, (shared[0].Y + shared[1].Y) / 2, (shared[0].Z + shared[1].Z) / 2}
+		for _, c := range []SphereCellID{end, start} {
+			cp := c.Point()
+			p := Point{m.X + (cp.X-m.X)/100, m.Y + (cp.Y-m.Y)/100, m.Z + (cp.Z-m.Z)/100}
+			if got := SphereCellIDFromPoint(p); got != c {
+				t.Errorf("face %d: point by the seam %v is in %x want %x", face, p, got, c)
+			}
+		}
+	}
+}
+
+// TestSphereCellIDAntipodal checks antipodal points are on opposite faces, at the same position
+// along the curve.
+func TestSphereCellIDAntipodal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		p := PointFromLatLng(randomLatLng(r))
+		q := Point{-p.X, -p.Y, -p.Z}
+		a, b := SphereCellIDFromPoint(p), SphereCellIDFromPoint(q)
+
+		if b.Face() != (a.Face()+3)%NumFaces {
+			t.Errorf("antipode of %v on face %d is on face %d", p, a.Face(), b.Face())
+		}
+		if a.Pos() != b.Pos() {
+			t.Errorf("antipode of %v at pos %d is at pos %d", p, a.Pos(), b.Pos())
+		}
+		ca, cb := a.Point(), b.Point()
+		if !closePoints(ca, Point{-cb.X, -cb.Y, -cb.Z}) {
+			t.Errorf("centre of %x = %v is not antipodal to centre of %x = %v", a, ca, b, cb)
+		}
+	}
+
+	// The poles.
+	north := SphereCellIDFromLatLng(LatLng{90, 0})
+	south := SphereCellIDFromLatLng(LatLng{-90, 0})
+	if north.Face() != 2 || south.Face() != 5 || north.Pos() != south.Pos() {
+		t.Errorf("poles are at %x and %x want faces 2 and 5 at the same pos", north, south)
+	}
+}
+
+func BenchmarkSphereCellIDFromLatLng(b *testing.B) {
+	p := LatLng{Lat: 51.5, Lng: -0.12}
+	for i := 0; i < b.N; i++ {
+		SphereCellIDFromLatLng(p)
+	}
+}
@@ -20,10 +20,12 @@ package hilbert
 // Implements SpaceFilling interface.
 type Hilbert struct {
 	N int
+
+	table *stateTable // Optional table to step through many bits at a time
 }
 
 // NewHilbert returns a Hilbert space which maps integers to and from the curve.
-// n must be a power of two.
+// n must be a power of two, and n^2 must fit in an int.
 func NewHilbert(n int) (*Hilbert, error) {
 	if n <= 0 {
 		return nil, ErrNotPositive
@@ -34,6 +36,11 @@ func NewHilbert(n int) (*Hilbert, error) {
 		return nil, ErrNotPowerOfTwo
 	}
 
+	// Test if n^2, the size of the space, fits in an int
+	if n > maxInt/n {
+		return nil, ErrOverflow
+	}
+
 	return &Hilbert{
 		N: n,
 	}, nil
@@ -51,6 +58,11 @@ func (s *Hilbert) Map(t int) (x, y int, err error) {
 		return -1, -1, ErrOutOfRange
 	}
 
+	if s.table != nil {
+		px, py := s.mapTable(uint64(t))
+		return int(px), int(py), nil
+	}
+
 	for i := 1; i < s.N; i = i * 2 {
 		rx := t&2 == 2
 		ry := t&1 == 1
@@ -79,6 +91,10 @@ func (s *Hilbert) MapInverse(x, y int) (t int, err error) {
 		return -1, ErrOutOfRange
 	}
 
+	if s.table != nil {
+		return int(s.mapInverseTable(uint32(x), uint32(y))), nil
+	}
+
 	for i := s.N / 2; i > 0; i = i / 2 {
 		rx := (x & i) > 0
 		ry := (y & i) > 0
@@ -95,6 +111,60 @@ func (s *Hilbert) MapInverse(x, y int) (t int, err error) {
 	return
 }
 
+// MapBatch transforms each value in ts to coordinates on the Hilbert curve, storing them in the
+// same position in xs and ys. The slices must be the same length. If any value is out of range
+// ErrOutOfRange is returned, and the coordinates of the values before it are still stored.
+func (s *Hilbert) MapBatch(ts []uint64, xs, ys []uint32) error {
+	if len(xs) != len(ts) || len(ys) != len(ts) {
+		return ErrLengthMismatch
+	}
+	xs, ys = xs[:len(ts)], ys[:len(ts)]
+
+	table, levels := s.batchTable()
+	size := uint64(s.N) * uint64(s.N)
+
+	for j, t := range ts {
+		if t >= size {
+			return ErrOutOfRange
+		}
+		xs[j], ys[j] = mortonDecode(table.hilbertToMorton(t, levels))
+	}
+	return nil
+}
+
+// MapInverseBatch transforms each pair of coordinates in xs and ys to a value on the Hilbert
+// curve, storing them in the same position in ts. The slices must be the same length. If any
+// pair is out of range ErrOutOfRange is returned, and the values of the pairs before it are
+// still stored.
+func (s *Hilbert) MapInverseBatch(xs, ys []uint32, ts []uint64) error {
+	if len(ys) != len(xs) || len(ts) != len(xs) {
+		return ErrLengthMismatch
+	}
+	ys, ts = ys[:len(xs)], ts[:len(xs)]
+
+	table, levels := s.batchTable()
+	n := uint32(s.N)
+
+	for j, x := range xs {
+		y := ys[j]
+		if x >= n || y >= n {
+			return ErrOutOfRange
+		}
+		ts[j] = table.mortonToHilbert(mortonEncode(x, y), levels)
+	}
+	return nil
+}
+
+// batchTable returns the state table, and number of levels, for the batch methods. They always
+// step through the curve with a table, as building the small 4 bit table once is soon repaid.
+func (s *Hilbert) batchTable() (*stateTable, uint) {
+	table := s.table
+	if table == nil {
+		table = getStateTable(4)
+	}
+	return table, uint(s.log2N())
+}
+
 // rotate rotates and flips the quadrant appropriately.
 func (s *Hilbert) rotate(n, x, y int, rx, ry bool) (int, int) {
 	if !ry {
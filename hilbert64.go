@@ -0,0 +1,129 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+// Hilbert64 represents a 2D Hilbert space of order N, for mapping to and from, with 64 bit
+// indexes. It produces the same curve as Hilbert, but N may be as large as 2^32.
+// Implements SpaceFilling64 interface.
+type Hilbert64 struct {
+	N uint64
+
+	max uint64 // Largest valid index, N^2 - 1.
+}
+
+// NewHilbert64 returns a Hilbert space which maps integers to and from the curve.
+// n must be a power of two, no larger than 2^32.
+func NewHilbert64(n uint64) (*Hilbert64, error) {
+	max, err := newMax64(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Hilbert64{
+		N:   n,
+		max: max,
+	}, nil
+}
+
+// newMax64 validates n, the width of a space indexed by a uint64, and returns the largest
+// index in the space.
+func newMax64(n uint64) (uint64, error) {
+	if n == 0 {
+		return 0, ErrNotPositive
+	}
+
+	// Test if power of two
+	if (n & (n - 1)) != 0 {
+		return 0, ErrNotPowerOfTwo
+	}
+
+	if n > 1<<32 {
+		return 0, ErrOverflow
+	}
+
+	// When n is 2^32, n*n wraps to zero, and this correctly becomes 2^64-1.
+	return n*n - 1, nil
+}
+
+// GetDimensions returns the width and height of the 2D space.
+func (s *Hilbert64) GetDimensions() (uint64, uint64) {
+	return s.N, s.N
+}
+
+// Map transforms a one dimension value, t, in the range [0, n^2-1] to coordinates on the Hilbert
+// curve in the two-dimension space, where x and y are within [0,n-1].
+func (s *Hilbert64) Map(t uint64) (x, y uint32, err error) {
+	if t > s.max {
+		return 0, 0, ErrOutOfRange
+	}
+
+	var px, py uint64
+	for i := uint64(1); i < s.N; i = i * 2 {
+		rx := t&2 == 2
+		ry := t&1 == 1
+		if rx {
+			ry = !ry
+		}
+
+		px, py = rotate64(i, px, py, rx, ry)
+
+		if rx {
+			px = px + i
+		}
+		if ry {
+			py = py + i
+		}
+
+		t /= 4
+	}
+
+	return uint32(px), uint32(py), nil
+}
+
+// MapInverse transform coordinates on Hilbert curve from (x,y) to t.
+func (s *Hilbert64) MapInverse(x, y uint32) (t uint64, err error) {
+	px, py := uint64(x), uint64(y)
+	if px >= s.N || py >= s.N {
+		return 0, ErrOutOfRange
+	}
+
+	for i := s.N / 2; i > 0; i = i / 2 {
+		rx := (px & i) > 0
+		ry := (py & i) > 0
+
+		var a uint64
+		if rx {
+			a = 3
+		}
+		t += i * i * (a ^ uint64(b2i(ry)))
+
+		px, py = rotate64(i, px, py, rx, ry)
+	}
+
+	return
+}
+
+// rotate64 rotates and flips the quadrant appropriately.
+func rotate64(n, x, y uint64, rx, ry bool) (uint64, uint64) {
+	if !ry {
+		if rx {
+			x = n - 1 - x
+			y = n - 1 - y
+		}
+
+		x, y = y, x
+	}
+	return x, y
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Check the 64 bit types implement the interface.
+var (
+	_ SpaceFilling64 = &Hilbert64{}
+	_ SpaceFilling64 = &Morton64{}
+)
+
+func TestNew64Errors(t *testing.T) {
+	var newTestCases = []struct {
+		n       uint64
+		wantErr error
+	}{
+		{0, ErrNotPositive},
+		{3, ErrNotPowerOfTwo},
+		{5, ErrNotPowerOfTwo},
+		{1<<32 + 1, ErrNotPowerOfTwo},
+		{1 << 33, ErrOverflow},
+		{1 << 63, ErrOverflow},
+	}
+
+	for _, tc := range newTestCases {
+		if s, err := NewHilbert64(tc.n); s != nil || err != tc.wantErr {
+			t.Errorf("NewHilbert64(%d) = (%+v, %q) did not fail want (nil, %q)", tc.n, s, err, tc.wantErr)
+		}
+		if s, err := NewMorton64(tc.n); s != nil || err != tc.wantErr {
+			t.Errorf("NewMorton64(%d) = (%+v, %q) did not fail want (nil, %q)", tc.n, s, err, tc.wantErr)
+		}
+	}
+}
+
+func TestHilbert64RangeErrors(t *testing.T) {
+	var testCases = []struct {
+		n       uint64
+		d       uint64
+		x, y    uint32
+		wantErr error
+	}{
+		{16, 255, 15, 15, nil},
+		{16, 256, 16, 0, ErrOutOfRange},
+		{16, 1<<64 - 1, 0, 16, ErrOutOfRange},
+		{1 << 31, 1<<62 - 1, 1<<31 - 1, 1<<31 - 1, nil},
+		{1 << 31, 1 << 62, 1 << 31, 0, ErrOutOfRange},
+		{1 << 32, 1<<64 - 1, 1<<32 - 1, 1<<32 - 1, nil},
+	}
+
+	for _, tc := range testCases {
+		s, err := NewHilbert64(tc.n)
+		if err != nil {
+			t.Fatalf("NewHilbert64(%d) failed: %s", tc.n, err)
+		}
+		if _, _, err := s.Map(tc.d); err != tc.wantErr {
+			t.Errorf("N=%d: Map(%d) = %q want %q", tc.n, tc.d, err, tc.wantErr)
+		}
+		if _, err := s.MapInverse(tc.x, tc.y); err != tc.wantErr {
+			t.Errorf("N=%d: MapInverse(%d, %d) = %q want %q", tc.n, tc.x, tc.y, err, tc.wantErr)
+		}
+	}
+}
+
+// TestHilbert64MatchesHilbert checks Hilbert64 produces the same curve as Hilbert.
+func TestHilbert64MatchesHilbert(t *testing.T) {
+	for _, n := range []int{1, 2, 4, 8, 16, 32, 64} {
+		h, err := NewHilbert(n)
+		if err != nil {
+			t.Fatalf("NewHilbert(%d) failed: %s", n, err)
+		}
+		s, err := NewHilbert64(uint64(n))
+		if err != nil {
+			t.Fatalf("NewHilbert64(%d) failed: %s", n, err)
+		}
+
+		for d := 0; d < n*n; d++ {
+			wantX, wantY, _ := h.Map(d)
+			x, y, err := s.Map(uint64(d))
+			if err != nil {
+				t.Errorf("N=%d: Map(%d) returned error: %s", n, d, err)
+			}
+			if int(x) != wantX || int(y) != wantY {
+				t.Errorf("N=%d: Map(%d) = (%d, %d) want (%d, %d)", n, d, x, y, wantX, wantY)
+			}
+
+			dPrime, err := s.MapInverse(x, y)
+			if err != nil {
+				t.Errorf("N=%d: MapInverse(%d, %d) returned error: %s", n, x, y, err)
+			}
+			if uint64(d) != dPrime {
+				t.Errorf("N=%d: Failed Map(%d) -> MapInverse(%d, %d) -> %d", n, d, x, y, dPrime)
+			}
+		}
+	}
+}
+
+func TestMorton64MatchesMorton(t *testing.T) {
+	m, err := NewMorton(32)
+	if err != nil {
+		t.Fatalf("NewMorton(32) failed: %s", err)
+	}
+	s, err := NewMorton64(32)
+	if err != nil {
+		t.Fatalf("NewMorton64(32) failed: %s", err)
+	}
+
+	for d := 0; d < 32*32; d++ {
+		wantX, wantY, _ := m.Map(d)
+		x, y, err := s.Map(uint64(d))
+		if err != nil {
+			t.Errorf("Map(%d) returned error: %s", d, err)
+		}
+		if int(x) != wantX || int(y) != wantY {
+			t.Errorf("Map(%d) = (%d, %d) want (%d, %d)", d, x, y, wantX, wantY)
+		}
+	}
+}
+
+// TestLargest64 round trips random values on the largest curves.
+func TestLargest64(t *testing.T) {
+	curves := map[string]SpaceFilling64{}
+	curves["Hilbert64"], _ = NewHilbert64(1 << 32)
+	curves["Morton64"], _ = NewMorton64(1 << 32)
+
+	r := rand.New(rand.NewSource(1))
+	for name, s := range curves {
+		for i := 0; i < 10000; i++ {
+			d := r.Uint64()
+			switch i {
+			case 0:
+				d = 0
+			case 1:
+				d = 1<<64 - 1
+			}
+
+			x, y, err := s.Map(d)
+			if err != nil {
+				t.Errorf("%s: Map(%d) returned error: %s", name, d, err)
+			}
+			dPrime, err := s.MapInverse(x, y)
+			if err != nil {
+				t.Errorf("%s: MapInverse(%d, %d) returned error: %s", name, x, y, err)
+			}
+			if d != dPrime {
+				t.Errorf("%s: Failed Map(%d) -> MapInverse(%d, %d) -> %d", name, d, x, y, dPrime)
+			}
+		}
+	}
+}
+
+func BenchmarkHilbert64Map(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s, err := NewHilbert64(benchmarkN)
+		if err != nil {
+			b.Fatalf("NewHilbert64(%d) failed: %s", benchmarkN, err)
+		}
+		for d := uint64(0); d < benchmarkN*benchmarkN; d++ {
+			s.Map(d)
+		}
+	}
+}
+
+func BenchmarkHilbert64MapInverse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s, err := NewHilbert64(benchmarkN)
+		if err != nil {
+			b.Fatalf("NewHilbert64(%d) failed: %s", benchmarkN, err)
+		}
+
+		for x := uint32(0); x < benchmarkN; x++ {
+			for y := uint32(0); y < benchmarkN; y++ {
+				s.MapInverse(x, y)
+			}
+		}
+	}
+}
@@ -54,6 +54,7 @@ func TestNewErrors(t *testing.T) {
 		{0, ErrNotPositive},
 		{3, ErrNotPowerOfTwo},
 		{5, ErrNotPowerOfTwo},
+		{1 << (uintSize / 2), ErrOverflow},
 	}
 
 	for _, tc := range newTestCases {
@@ -234,3 +235,99 @@ func BenchmarkMapInverse(b *testing.B) {
 		}
 	}
 }
+
+func TestMapBatch(t *testing.T) {
+	s, err := NewHilbert(16)
+	if err != nil {
+		t.Fatalf("Failed to create hibert space: %s", err)
+	}
+
+	size := s.N * s.N
+	ts := make([]uint64, size)
+	for d := range ts {
+		ts[d] = uint64(d)
+	}
+	xs, ys := make([]uint32, size), make([]uint32, size)
+
+	if err := s.MapBatch(ts, xs, ys); err != nil {
+		t.Fatalf("MapBatch returned error: %s", err)
+	}
+	for d := range ts {
+		x, y, _ := s.Map(d)
+		if xs[d] != uint32(x) || ys[d] != uint32(y) {
+			t.Errorf("MapBatch(%d) failed, want (%d, %d), got (%d, %d)", d, x, y, xs[d], ys[d])
+		}
+	}
+
+	tsPrime := make([]uint64, size)
+	if err := s.MapInverseBatch(xs, ys, tsPrime); err != nil {
+		t.Fatalf("MapInverseBatch returned error: %s", err)
+	}
+	for d := range ts {
+		if tsPrime[d] != ts[d] {
+			t.Errorf("MapInverseBatch(%d, %d) failed, want %d, got %d", xs[d], ys[d], ts[d], tsPrime[d])
+		}
+	}
+}
+
+func TestMapBatchErrors(t *testing.T) {
+	s, err := NewHilbert(16)
+	if err != nil {
+		t.Fatalf("Failed to create hibert space: %s", err)
+	}
+
+	var testCases = []struct {
+		ts      []uint64
+		xs, ys  []uint32
+		wantErr error
+	}{
+		{[]uint64{0, 255}, []uint32{0, 15}, []uint32{0, 0}, nil},
+		{[]uint64{0, 256}, []uint32{0, 16}, []uint32{0, 0}, ErrOutOfRange},
+		{[]uint64{0, 1}, []uint32{0}, []uint32{0, 0}, ErrLengthMismatch},
+		{[]uint64{0, 1}, []uint32{0, 0}, []uint32{0}, ErrLengthMismatch},
+		{[]uint64{0}, []uint32{0, 0}, []uint32{0, 0}, ErrLengthMismatch},
+	}
+
+	for _, tc := range testCases {
+		if err := s.MapBatch(tc.ts, tc.xs, tc.ys); err != tc.wantErr {
+			t.Errorf("MapBatch(%v) did not fail, want %q, got %q", tc.ts, tc.wantErr, err)
+		}
+		if err := s.MapInverseBatch(tc.xs, tc.ys, tc.ts); err != tc.wantErr {
+			t.Errorf("MapInverseBatch(%v, %v) did not fail, want %q, got %q", tc.xs, tc.ys, tc.wantErr, err)
+		}
+	}
+}
+
+func BenchmarkMapBatch(b *testing.B) {
+	ts := make([]uint64, benchmarkN*benchmarkN)
+	for d := range ts {
+		ts[d] = uint64(d)
+	}
+	xs, ys := make([]uint32, len(ts)), make([]uint32, len(ts))
+
+	for i := 0; i < b.N; i++ {
+		s, err := NewHilbert(benchmarkN)
+		if err != nil {
+			b.Fatalf("Failed to create hibert space: %s", err)
+		}
+		s.MapBatch(ts, xs, ys)
+	}
+}
+
+func BenchmarkMapInverseBatch(b *testing.B) {
+	xs, ys := make([]uint32, benchmarkN*benchmarkN), make([]uint32, benchmarkN*benchmarkN)
+	for x := 0; x < benchmarkN; x++ {
+		for y := 0; y < benchmarkN; y++ {
+			xs[x*benchmarkN+y], ys[x*benchmarkN+y] = uint32(x), uint32(y)
+		}
+	}
+	ts := make([]uint64, len(xs))
+
+	for i := 0; i < b.N; i++ {
+		s, err := NewHilbert(benchmarkN)
+		if err != nil {
+			b.Fatalf("Failed to create hibert space: %s", err)
+		}
+		s.MapInverseBatch(xs, ys, ts)
+	}
+}
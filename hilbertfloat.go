@@ -0,0 +1,123 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import "math"
+
+// maxFloatPrecision is the largest precision supported by HilbertFloat. A float64 has a 53 bit
+// mantissa, so any more than 26 bits per axis could not be represented in the position.
+const maxFloatPrecision = 26
+
+// HilbertFloat maps between positions along a Hilbert curve, in the range [0,1), and points in
+// a rectangle, which by default is the unit square [0,1)x[0,1).
+//
+// The rectangle is split into a grid of 2^Precision() by 2^Precision() cells, which are mapped
+// with Hilbert. Map returns the centre of the cell containing the position, and MapInverse returns
+// the centre of the range of positions covered by the cell containing the point, so mapping
+// back and forth always returns to the same cell.
+//
+// Values on the upper edges, or outside the range, are clamped to the nearest cell, so 1 is
+// treated as being in the last cell, and any point outside the rectangle as being in the cell
+// on the nearest edge. NaNs map to NaNs.
+type HilbertFloat struct {
+	// Rectangle being mapped to and from.
+	MinX, MinY, MaxX, MaxY float64
+
+	curve *Hilbert
+}
+
+// NewHilbertFloat returns a HilbertFloat which maps to and from the unit square, with precision
+// bits per axis. precision must be between 1 and 26, or 15 on 32 bit platforms.
+func NewHilbertFloat(precision int) (*HilbertFloat, error) {
+	return NewHilbertFloatExtent(precision, 0, 0, 1, 1)
+}
+
+// NewHilbertFloatExtent returns a HilbertFloat which maps to and from the rectangle
+// [minX,maxX)x[minY,maxY), with precision bits per axis. precision must be between 1 and 26,
+// or 15 on 32 bit platforms.
+func NewHilbertFloatExtent(precision int, minX, minY, maxX, maxY float64) (*HilbertFloat, error) {
+	if precision <= 0 {
+		return nil, ErrNotPositive
+	}
+	if precision > maxFloatPrecision {
+		return nil, ErrOverflow
+	}
+
+	// Written so NaNs and infinities are also rejected.
+	if !(maxX > minX && maxY > minY) || math.IsInf(maxX-minX, 0) || math.IsInf(maxY-minY, 0) {
+		return nil, ErrInvalidExtent
+	}
+
+	curve, err := NewHilbert(1 << uint(precision))
+	if err != nil {
+		return nil, err
+	}
+
+	return &HilbertFloat{
+		MinX:  minX,
+		MinY:  minY,
+		MaxX:  maxX,
+		MaxY:  maxY,
+		curve: curve,
+	}, nil
+}
+
+// Precision returns the number of bits per axis of the grid of cells.
+func (s *HilbertFloat) Precision() int {
+	return s.curve.log2N()
+}
+
+// Map transforms a position along the curve, f, in the range [0,1), to the centre of the cell
+// containing it.
+func (s *HilbertFloat) Map(f float64) (x, y float64) {
+	if math.IsNaN(f) {
+		return math.NaN(), math.NaN()
+	}
+
+	n := s.curve.N
+	cx, cy, _ := s.curve.Map(cell(f, n*n))
+
+	x = s.MinX + (float64(cx)+0.5)/float64(n)*(s.MaxX-s.MinX)
+	y = s.MinY + (float64(cy)+0.5)/float64(n)*(s.MaxY-s.MinY)
+	return x, y
+}
+
+// MapInverse transforms a point, (x,y), to the centre of the range of positions along the curve
+// covered by the cell containing it.
+func (s *HilbertFloat) MapInverse(x, y float64) float64 {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return math.NaN()
+	}
+
+	n := s.curve.N
+	cx := cell((x-s.MinX)/(s.MaxX-s.MinX), n)
+	cy := cell((y-s.MinY)/(s.MaxY-s.MinY), n)
+	t, _ := s.curve.MapInverse(cx, cy)
+
+	return (float64(t) + 0.5) / float64(n*n)
+}
+
+// cell returns which of n equal cells, covering [0,1), contains f. Values outside of the range
+// are clamped to the first or last cell.
+func cell(f float64, n int) int {
+	f *= float64(n)
+	if f < 0 {
+		return 0
+	}
+	if f >= float64(n) {
+		return n - 1
+	}
+	return int(f)
+}
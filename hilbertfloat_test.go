@@ -0,0 +1,177 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestHilbertFloatNewErrors(t *testing.T) {
+	var newTestCases = []struct {
+		precision              int
+		minX, minY, maxX, maxY float64
+		wantErr                error
+	}{
+		{0, 0, 0, 1, 1, ErrNotPositive},
+		{-1, 0, 0, 1, 1, ErrNotPositive},
+		{27, 0, 0, 1, 1, ErrOverflow},
+		{8, 0, 0, 0, 1, ErrInvalidExtent},
+		{8, 0, 1, 1, 0, ErrInvalidExtent},
+		{8, 0, 0, math.NaN(), 1, ErrInvalidExtent},
+		{8, 0, 0, math.Inf(1), 1, ErrInvalidExtent},
+		{8, -math.MaxFloat64, 0, math.MaxFloat64, 1, ErrInvalidExtent},
+	}
+
+	for _, tc := range newTestCases {
+		s, err := NewHilbertFloatExtent(tc.precision, tc.minX, tc.minY, tc.maxX, tc.maxY)
+		if s != nil || err != tc.wantErr {
+			t.Errorf("NewHilbertFloatExtent(%d, %g, %g, %g, %g) = (%+v, %q) did not fail want (nil, %q)",
+				tc.precision, tc.minX, tc.minY, tc.maxX, tc.maxY, s, err, tc.wantErr)
+		}
+	}
+}
+
+// TestHilbertFloatMatchesHilbert checks the centre of every cell maps the same as Hilbert.
+func TestHilbertFloatMatchesHilbert(t *testing.T) {
+	s, err := NewHilbertFloat(4)
+	if err != nil {
+		t.Fatalf("NewHilbertFloat(4) failed: %s", err)
+	}
+	h, _ := NewHilbert(16)
+	if p := s.Precision(); p != 4 {
+		t.Errorf("Precision() = %d want 4", p)
+	}
+
+	for d := 0; d < 256; d++ {
+		hx, hy, _ := h.Map(d)
+		wantX, wantY := (float64(hx)+0.5)/16, (float64(hy)+0.5)/16
+		wantF := (float64(d) + 0.5) / 256
+
+		// Anywhere within the range of d maps to the centre of its cell.
+		for _, f := range []float64{float64(d) / 256, wantF, math.Nextafter(float64(d+1)/256, 0)} {
+			if x, y := s.Map(f); x != wantX || y != wantY {
+				t.Errorf("Map(%g) = (%g, %g) want (%g, %g)", f, x, y, wantX, wantY)
+			}
+		}
+
+		if f := s.MapInverse(wantX, wantY); f != wantF {
+			t.Errorf("MapInverse(%g, %g) = %g want %g", wantX, wantY, f, wantF)
+		}
+		if f := s.MapInverse(float64(hx)/16, float64(hy)/16); f != wantF {
+			t.Errorf("MapInverse(%g, %g) = %g want %g", float64(hx)/16, float64(hy)/16, f, wantF)
+		}
+	}
+}
+
+func TestHilbertFloatEdges(t *testing.T) {
+	s, err := NewHilbertFloat(4)
+	if err != nil {
+		t.Fatalf("NewHilbertFloat(4) failed: %s", err)
+	}
+
+	first, last := 0.5/256, 255.5/256
+	var mapTestCases = []struct {
+		f, wantX, wantY float64
+	}{
+		{0, 0.5 / 16, 0.5 / 16},
+		{-1, 0.5 / 16, 0.5 / 16},
+		{math.Inf(-1), 0.5 / 16, 0.5 / 16},
+		{1, 15.5 / 16, 0.5 / 16},
+		{2, 15.5 / 16, 0.5 / 16},
+		{math.Inf(1), 15.5 / 16, 0.5 / 16},
+	}
+	for _, tc := range mapTestCases {
+		if x, y := s.Map(tc.f); x != tc.wantX || y != tc.wantY {
+			t.Errorf("Map(%g) = (%g, %g) want (%g, %g)", tc.f, x, y, tc.wantX, tc.wantY)
+		}
+	}
+
+	var mapInverseTestCases = []struct {
+		x, y, want float64
+	}{
+		{0, 0, first},
+		{-5, -5, first},
+		{1, 0, last},
+		{100, -1, last},
+		{math.Inf(1), math.Inf(-1), last},
+	}
+	for _, tc := range mapInverseTestCases {
+		if f := s.MapInverse(tc.x, tc.y); f != tc.want {
+			t.Errorf("MapInverse(%g, %g) = %g want %g", tc.x, tc.y, f, tc.want)
+		}
+	}
+
+	if x, y := s.Map(math.NaN()); !math.IsNaN(x) || !math.IsNaN(y) {
+		t.Errorf("Map(NaN) = (%g, %g) want (NaN, NaN)", x, y)
+	}
+	if f := s.MapInverse(0.5, math.NaN()); !math.IsNaN(f) {
+		t.Errorf("MapInverse(0.5, NaN) = %g want NaN", f)
+	}
+}
+
+// TestHilbertFloatRoundTrip checks random values map back and forth to within a cell.
+func TestHilbertFloatRoundTrip(t *testing.T) {
+	precision := 20
+	if uintSize == 32 {
+		precision = 15
+	}
+	s, err := NewHilbertFloatExtent(precision, -180, -90, 180, 90)
+	if err != nil {
+		t.Fatalf("NewHilbertFloatExtent(%d) failed: %s", precision, err)
+	}
+	n := float64(int(1) << uint(precision))
+	cellWidth, cellHeight := 360/n, 180/n
+	cellSize := 1 / (n * n)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		f := r.Float64()
+		x, y := s.Map(f)
+		if x < -180 || x >= 180 || y < -90 || y >= 90 {
+			t.Errorf("Map(%g) = (%g, %g) is outside the extent", f, x, y)
+		}
+		if fPrime := s.MapInverse(x, y); math.Abs(fPrime-f) > cellSize {
+			t.Errorf("Failed Map(%g) -> MapInverse(%g, %g) -> %g", f, x, y, fPrime)
+		}
+
+		x, y = r.Float64()*360-180, r.Float64()*180-90
+		f = s.MapInverse(x, y)
+		if xPrime, yPrime := s.Map(f); math.Abs(xPrime-x) > cellWidth/2 || math.Abs(yPrime-y) > cellHeight/2 {
+			t.Errorf("Failed MapInverse(%g, %g) -> Map(%g) -> (%g, %g)", x, y, f, xPrime, yPrime)
+		}
+	}
+}
+
+func BenchmarkHilbertFloatMap(b *testing.B) {
+	s, err := NewHilbertFloat(15)
+	if err != nil {
+		b.Fatalf("NewHilbertFloat(15) failed: %s", err)
+	}
+	for i := 0; i < b.N; i++ {
+		s.Map(float64(i%1000) / 1000)
+	}
+}
+
+func BenchmarkHilbertFloatMapInverse(b *testing.B) {
+	s, err := NewHilbertFloat(15)
+	if err != nil {
+		b.Fatalf("NewHilbertFloat(15) failed: %s", err)
+	}
+	for i := 0; i < b.N; i++ {
+		s.MapInverse(float64(i%1000)/1000, float64(i%997)/997)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+// uintSize is the size of a uint in bits.
+const uintSize = 32 << (^uint(0) >> 63)
+
+// HilbertND represents a Hilbert space with any number of dimensions, each of width N.
+//
+// It is based on John Skilling's "Programming the Hilbert curve" (2004), which works on the
+// "transpose" of the index. The bits of t are dealt out in turn to each dimension, the first
+// dimension receiving the most significant bit, and the coordinates are derived from the
+// result with a Gray code and a series of bit exchanges. With two dimensions it produces the
+// same curve as Hilbert.
+type HilbertND struct {
+	N    int // Always a power of two, and is the width of each dimension.
+	Dims int // Number of dimensions.
+
+	bits uint // log2(N)
+	max  uint // Largest valid index, N^Dims - 1.
+}
+
+// NewHilbertND returns a Hilbert space of dims dimensions which maps integers to and from the
+// curve. n must be a power of two, and n^dims must fit in a uint.
+func NewHilbertND(n, dims int) (*HilbertND, error) {
+	bits, err := validateND(n, dims)
+	if err != nil {
+		return nil, err
+	}
+
+	if bits*uint(dims) > uintSize {
+		return nil, ErrOverflow
+	}
+
+	max := ^uint(0)
+	if bits*uint(dims) < uintSize {
+		max = (1 << (bits * uint(dims))) - 1
+	}
+
+	return &HilbertND{
+		N:    n,
+		Dims: dims,
+		bits: bits,
+		max:  max,
+	}, nil
+}
+
+// validateND checks n and dims are valid for a N-dimensional Hilbert space, and returns the
+// number of bits in each coordinate.
+func validateND(n, dims int) (bits uint, err error) {
+	if n <= 0 {
+		return 0, ErrNotPositive
+	}
+
+	// Test if power of two
+	if (n & (n - 1)) != 0 {
+		return 0, ErrNotPowerOfTwo
+	}
+
+	if dims <= 0 {
+		return 0, ErrDimensionsNotPositive
+	}
+
+	for (1 << bits) < n {
+		bits++
+	}
+	return bits, nil
+}
+
+// GetDimensions returns the width of each dimension of the space.
+func (s *HilbertND) GetDimensions() []int {
+	dims := make([]int, s.Dims)
+	for i := range dims {
+		dims[i] = s.N
+	}
+	return dims
+}
+
+// Map transforms a one dimension value, t, in the range [0, n^dims-1] to coordinates on the
+// Hilbert curve in the dims-dimension space, where each coordinate is within [0,n-1].
+func (s *HilbertND) Map(t uint) ([]uint, error) {
+	if t > s.max {
+		return nil, ErrOutOfRange
+	}
+
+	x := make([]uint, s.Dims)
+
+	// Deal out the bits of t, most significant first, to form the transpose.
+	for j := int(s.bits) - 1; j >= 0; j-- {
+		for i := range x {
+			shift := uint(j)*uint(s.Dims) + uint(s.Dims-1-i)
+			x[i] |= ((t >> shift) & 1) << uint(j)
+		}
+	}
+
+	transposeToAxes(x, s.bits)
+	return x, nil
+}
+
+// MapInverse transform coordinates on the Hilbert curve from coords to t.
+func (s *HilbertND) MapInverse(coords []uint) (t uint, err error) {
+	if len(coords) != s.Dims {
+		return 0, ErrWrongDimensions
+	}
+	for _, c := range coords {
+		if c >= uint(s.N) {
+			return 0, ErrOutOfRange
+		}
+	}
+
+	x := make([]uint, s.Dims)
+	copy(x, coords)
+	axesToTranspose(x, s.bits)
+
+	// Gather the bits of the transpose back into t.
+	for j := int(s.bits) - 1; j >= 0; j-- {
+		for i := range x {
+			t = t<<1 | (x[i]>>uint(j))&1
+		}
+	}
+
+	return t, nil
+}
+
+// transposeToAxes converts the transpose of a Hilbert index, in place, to coordinates. bits is
+// the number of bits in each coordinate.
+func transposeToAxes(x []uint, bits uint) {
+	if bits == 0 {
+		return
+	}
+	n := len(x)
+
+	// Gray decode by H ^ (H/2)
+	t := x[n-1] >> 1
+	for i := n - 1; i > 0; i-- {
+		x[i] ^= x[i-1]
+	}
+	x[0] ^= t
+
+	// Undo excess work
+	for q := uint(2); q != 1<<bits; q <<= 1 {
+		p := q - 1
+		for i := n - 1; i >= 0; i-- {
+			if x[i]&q != 0 {
+				x[0] ^= p // invert
+			} else {
+				t := (x[0] ^ x[i]) & p // exchange
+				x[0] ^= t
+				x[i] ^= t
+			}
+		}
+	}
+}
+
+// axesToTranspose converts coordinates, in place, to the transpose of their Hilbert index. bits
+// is the number of bits in each coordinate.
+func axesToTranspose(x []uint, bits uint) {
+	if bits == 0 {
+		return
+	}
+	n := len(x)
+	m := uint(1) << (bits - 1)
+
+	// Inverse undo
+	for q := m; q > 1; q >>= 1 {
+		p := q - 1
+		for i := 0; i < n; i++ {
+			if x[i]&q != 0 {
+				x[0] ^= p // invert
+			} else {
+				t := (x[0] ^ x[i]) & p // exchange
+				x[0] ^= t
+				x[i] ^= t
+			}
+		}
+	}
+
+	// Gray encode
+	for i := 1; i < n; i++ {
+		x[i] ^= x[i-1]
+	}
+	t := uint(0)
+	for q := m; q > 1; q >>= 1 {
+		if x[n-1]&q != 0 {
+			t ^= q - 1
+		}
+	}
+	for i := range x {
+		x[i] ^= t
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHilbertNDNewErrors(t *testing.T) {
+	var newTestCases = []struct {
+		n, dims int
+		wantErr error
+	}{
+		{-1, 2, ErrNotPositive},
+		{0, 2, ErrNotPositive},
+		{3, 2, ErrNotPowerOfTwo},
+		{5, 3, ErrNotPowerOfTwo},
+		{4, 0, ErrDimensionsNotPositive},
+		{4, -1, ErrDimensionsNotPositive},
+		{1 << 16, uintSize/16 + 1, ErrOverflow},
+	}
+
+	for _, tc := range newTestCases {
+		s, err := NewHilbertND(tc.n, tc.dims)
+		if s != nil || err != tc.wantErr {
+			t.Errorf("NewHilbertND(%d, %d) = (%+v, %q) did not fail want (nil, %q)", tc.n, tc.dims, s, err, tc.wantErr)
+		}
+	}
+}
+
+func TestHilbertNDRangeErrors(t *testing.T) {
+	s, err := NewHilbertND(4, 3)
+	if err != nil {
+		t.Fatalf("NewHilbertND(4, 3) failed: %s", err)
+	}
+
+	var mapRangeTestCases = []struct {
+		d       uint
+		wantErr error
+	}{
+		{0, nil},
+		{63, nil},
+		{64, ErrOutOfRange},
+		{^uint(0), ErrOutOfRange},
+	}
+
+	for _, tc := range mapRangeTestCases {
+		if _, err = s.Map(tc.d); err != tc.wantErr {
+			t.Errorf("Map(%d) = %q want %q", tc.d, err, tc.wantErr)
+		}
+	}
+
+	var mapInverseRangeTestCases = []struct {
+		coords  []uint
+		wantErr error
+	}{
+		{[]uint{0, 0, 0}, nil},
+		{[]uint{3, 3, 3}, nil},
+		{[]uint{4, 0, 0}, ErrOutOfRange},
+		{[]uint{0, 0, 4}, ErrOutOfRange},
+		{[]uint{0, 0}, ErrWrongDimensions},
+		{[]uint{0, 0, 0, 0}, ErrWrongDimensions},
+	}
+
+	for _, tc := range mapInverseRangeTestCases {
+		if _, err = s.MapInverse(tc.coords); err != tc.wantErr {
+			t.Errorf("MapInverse(%v) = %q want %q", tc.coords, err, tc.wantErr)
+		}
+	}
+}
+
+// TestHilbertNDMatchesHilbert checks the two dimension curve is the same as Hilbert's.
+func TestHilbertNDMatchesHilbert(t *testing.T) {
+	for _, n := range []int{1, 2, 4, 8, 16, 32} {
+		h, err := NewHilbert(n)
+		if err != nil {
+			t.Fatalf("NewHilbert(%d) failed: %s", n, err)
+		}
+		s, err := NewHilbertND(n, 2)
+		if err != nil {
+			t.Fatalf("NewHilbertND(%d, 2) failed: %s", n, err)
+		}
+
+		for d := 0; d < n*n; d++ {
+			x, y, _ := h.Map(d)
+			coords, err := s.Map(uint(d))
+			if err != nil {
+				t.Errorf("N=%d: Map(%d) returned error: %s", n, d, err)
+				continue
+			}
+			if coords[0] != uint(x) || coords[1] != uint(y) {
+				t.Errorf("N=%d: Map(%d) = %v want [%d %d]", n, d, coords, x, y)
+			}
+		}
+	}
+}
+
+func TestHilbertNDAllMapValues(t *testing.T) {
+	var testCases = []struct {
+		n, dims int
+	}{
+		{1, 1}, {1, 3}, {8, 1}, {2, 2}, {16, 2}, {2, 3}, {8, 3}, {4, 4}, {2, 6},
+	}
+
+	for _, tc := range testCases {
+		s, err := NewHilbertND(tc.n, tc.dims)
+		if err != nil {
+			t.Fatalf("NewHilbertND(%d, %d) failed: %s", tc.n, tc.dims, err)
+		}
+
+		var prev []uint
+		for d := uint(0); d <= s.max; d++ {
+			// Map forwards and then back
+			coords, err := s.Map(d)
+			if err != nil {
+				t.Fatalf("N=%d Dims=%d: Map(%d) returned error: %s", tc.n, tc.dims, d, err)
+			}
+			for _, c := range coords {
+				if c >= uint(s.N) {
+					t.Errorf("N=%d Dims=%d: Map(%d) returned coordinates out of range: %v", tc.n, tc.dims, d, coords)
+				}
+			}
+
+			// Each point must be a single step from the previous one.
+			if prev != nil && distance(prev, coords) != 1 {
+				t.Errorf("N=%d Dims=%d: Map(%d) = %v is not adjacent to Map(%d) = %v", tc.n, tc.dims, d, coords, d-1, prev)
+			}
+			prev = coords
+
+			dPrime, err := s.MapInverse(coords)
+			if err != nil {
+				t.Errorf("N=%d Dims=%d: MapInverse(%v) returned error: %s", tc.n, tc.dims, coords, err)
+			}
+			if d != dPrime {
+				t.Errorf("N=%d Dims=%d: Failed Map(%d) -> MapInverse(%v) -> %d", tc.n, tc.dims, d, coords, dPrime)
+			}
+		}
+	}
+}
+
+func TestHilbertNDFullRange(t *testing.T) {
+	s, err := NewHilbertND(1<<16, uintSize/16)
+	if err != nil {
+		t.Fatalf("NewHilbertND(%d, %d) failed: %s", 1<<16, uintSize/16, err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		d := uint(r.Uint64())
+		if i == 0 {
+			d = ^uint(0)
+		}
+		coords, err := s.Map(d)
+		if err != nil {
+			t.Fatalf("Map(%d) returned error: %s", d, err)
+		}
+		dPrime, err := s.MapInverse(coords)
+		if err != nil || d != dPrime {
+			t.Errorf("Failed Map(%d) -> MapInverse(%v) -> (%d, %v)", d, coords, dPrime, err)
+		}
+	}
+}
+
+// distance returns the Manhattan distance between a and b.
+func distance(a, b []uint) uint {
+	var sum uint
+	for i := range a {
+		if a[i] > b[i] {
+			sum += a[i] - b[i]
+		} else {
+			sum += b[i] - a[i]
+		}
+	}
+	return sum
+}
+
+func BenchmarkHilbertNDMap(b *testing.B) {
+	s, err := NewHilbertND(benchmarkN, 3)
+	if err != nil {
+		b.Fatalf("NewHilbertND(%d, 3) failed: %s", benchmarkN, err)
+	}
+	for i := 0; i < b.N; i++ {
+		for d := uint(0); d <= s.max; d++ {
+			s.Map(d)
+		}
+	}
+}
+
+func BenchmarkHilbertNDMapInverse(b *testing.B) {
+	s, err := NewHilbertND(benchmarkN, 3)
+	if err != nil {
+		b.Fatalf("NewHilbertND(%d, 3) failed: %s", benchmarkN, err)
+	}
+	coords := make([]uint, 3)
+	for i := 0; i < b.N; i++ {
+		for x := 0; x < benchmarkN; x++ {
+			for y := 0; y < benchmarkN; y++ {
+				for z := 0; z < benchmarkN; z++ {
+					coords[0], coords[1], coords[2] = uint(x), uint(y), uint(z)
+					s.MapInverse(coords)
+				}
+			}
+		}
+	}
+}
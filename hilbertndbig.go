@@ -0,0 +1,102 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import "math/big"
+
+// HilbertNDBig represents a Hilbert space with any number of dimensions, each of width N, whose
+// indexes are arbitrary-precision integers. This allows spaces such as six dimensions of 2^32,
+// which need 192 bit indexes, to be used.
+//
+// It produces the same curve as HilbertND, and so with two dimensions the same curve as
+// Hilbert, but N^Dims is not limited by the size of a machine integer.
+type HilbertNDBig struct {
+	N    int // Always a power of two, and is the width of each dimension.
+	Dims int // Number of dimensions.
+
+	bits uint // log2(N)
+}
+
+// NewHilbertNDBig returns a Hilbert space of dims dimensions which maps big.Ints to and from
+// the curve. n must be a power of two.
+func NewHilbertNDBig(n, dims int) (*HilbertNDBig, error) {
+	bits, err := validateND(n, dims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HilbertNDBig{
+		N:    n,
+		Dims: dims,
+		bits: bits,
+	}, nil
+}
+
+// GetDimensions returns the width of each dimension of the space.
+func (s *HilbertNDBig) GetDimensions() []int {
+	dims := make([]int, s.Dims)
+	for i := range dims {
+		dims[i] = s.N
+	}
+	return dims
+}
+
+// Map transforms a one dimension value, t, in the range [0, n^dims-1] to coordinates on the
+// Hilbert curve in the dims-dimension space, where each coordinate is within [0,n-1].
+func (s *HilbertNDBig) Map(t *big.Int) ([]uint, error) {
+	if t.Sign() < 0 || uint(t.BitLen()) > s.bits*uint(s.Dims) {
+		return nil, ErrOutOfRange
+	}
+
+	x := make([]uint, s.Dims)
+
+	// Deal out the bits of t, most significant first, to form the transpose.
+	for j := int(s.bits) - 1; j >= 0; j-- {
+		for i := range x {
+			bit := j*s.Dims + s.Dims - 1 - i
+			x[i] |= t.Bit(bit) << uint(j)
+		}
+	}
+
+	transposeToAxes(x, s.bits)
+	return x, nil
+}
+
+// MapInverse transform coordinates on the Hilbert curve from coords to t.
+func (s *HilbertNDBig) MapInverse(coords []uint) (*big.Int, error) {
+	if len(coords) != s.Dims {
+		return nil, ErrWrongDimensions
+	}
+	for _, c := range coords {
+		if c >= uint(s.N) {
+			return nil, ErrOutOfRange
+		}
+	}
+
+	x := make([]uint, s.Dims)
+	copy(x, coords)
+	axesToTranspose(x, s.bits)
+
+	// Gather the bits of the transpose back into t.
+	t := new(big.Int)
+	for j := int(s.bits) - 1; j >= 0; j-- {
+		for i := range x {
+			bit := j*s.Dims + s.Dims - 1 - i
+			t.SetBit(t, bit, (x[i]>>uint(j))&1)
+		}
+	}
+
+	return t, nil
+}
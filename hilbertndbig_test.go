@@ -0,0 +1,224 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestHilbertNDBigNewErrors(t *testing.T) {
+	var newTestCases = []struct {
+		n, dims int
+		wantErr error
+	}{
+		{-1, 2, ErrNotPositive},
+		{0, 2, ErrNotPositive},
+		{3, 2, ErrNotPowerOfTwo},
+		{4, 0, ErrDimensionsNotPositive},
+	}
+
+	for _, tc := range newTestCases {
+		s, err := NewHilbertNDBig(tc.n, tc.dims)
+		if s != nil || err != tc.wantErr {
+			t.Errorf("NewHilbertNDBig(%d, %d) = (%+v, %q) did not fail want (nil, %q)", tc.n, tc.dims, s, err, tc.wantErr)
+		}
+	}
+}
+
+func TestHilbertNDBigRangeErrors(t *testing.T) {
+	s, err := NewHilbertNDBig(4, 3)
+	if err != nil {
+		t.Fatalf("NewHilbertNDBig(4, 3) failed: %s", err)
+	}
+
+	var mapRangeTestCases = []struct {
+		d       int64
+		wantErr error
+	}{
+		{-1, ErrOutOfRange},
+		{0, nil},
+		{63, nil},
+		{64, ErrOutOfRange},
+	}
+	for _, tc := range mapRangeTestCases {
+		if _, err = s.Map(big.NewInt(tc.d)); err != tc.wantErr {
+			t.Errorf("Map(%d) = %q want %q", tc.d, err, tc.wantErr)
+		}
+	}
+
+	var mapInverseRangeTestCases = []struct {
+		coords  []uint
+		wantErr error
+	}{
+		{[]uint{3, 3, 3}, nil},
+		{[]uint{4, 0, 0}, ErrOutOfRange},
+		{[]uint{0, 0}, ErrWrongDimensions},
+	}
+	for _, tc := range mapInverseRangeTestCases {
+		if _, err = s.MapInverse(tc.coords); err != tc.wantErr {
+			t.Errorf("MapInverse(%v) = %q want %q", tc.coords, err, tc.wantErr)
+		}
+	}
+}
+
+// TestHilbertNDBigMatchesHilbert cross checks the big.Int path against Hilbert.
+func TestHilbertNDBigMatchesHilbert(t *testing.T) {
+	for _, n := range []int{1, 2, 4, 8, 16, 32} {
+		h, err := NewHilbert(n)
+		if err != nil {
+			t.Fatalf("NewHilbert(%d) failed: %s", n, err)
+		}
+		s, err := NewHilbertNDBig(n, 2)
+		if err != nil {
+			t.Fatalf("NewHilbertNDBig(%d, 2) failed: %s", n, err)
+		}
+
+		for d := 0; d < n*n; d++ {
+			x, y, _ := h.Map(d)
+			coords, err := s.Map(big.NewInt(int64(d)))
+			if err != nil {
+				t.Errorf("N=%d: Map(%d) returned error: %s", n, d, err)
+				continue
+			}
+			if coords[0] != uint(x) || coords[1] != uint(y) {
+				t.Errorf("N=%d: Map(%d) = %v want [%d %d]", n, d, coords, x, y)
+			}
+
+			dPrime, err := s.MapInverse(coords)
+			if err != nil {
+				t.Errorf("N=%d: MapInverse(%v) returned error: %s", n, coords, err)
+			}
+			if dPrime.Cmp(big.NewInt(int64(d))) != 0 {
+				t.Errorf("N=%d: Failed Map(%d) -> MapInverse(%v) -> %s", n, d, coords, dPrime)
+			}
+		}
+	}
+}
+
+// TestHilbertNDBigMatchesHilbertND cross checks the big.Int path against HilbertND.
+func TestHilbertNDBigMatchesHilbertND(t *testing.T) {
+	var testCases = []struct {
+		n, dims int
+	}{
+		{1, 3}, {8, 1}, {2, 3}, {8, 3}, {4, 4}, {2, 6},
+	}
+
+	for _, tc := range testCases {
+		h, err := NewHilbertND(tc.n, tc.dims)
+		if err != nil {
+			t.Fatalf("NewHilbertND(%d, %d) failed: %s", tc.n, tc.dims, err)
+		}
+		s, err := NewHilbertNDBig(tc.n, tc.dims)
+		if err != nil {
+			t.Fatalf("NewHilbertNDBig(%d, %d) failed: %s", tc.n, tc.dims, err)
+		}
+
+		for d := uint(0); d <= h.max; d++ {
+			want, _ := h.Map(d)
+			coords, err := s.Map(new(big.Int).SetUint64(uint64(d)))
+			if err != nil {
+				t.Errorf("N=%d Dims=%d: Map(%d) returned error: %s", tc.n, tc.dims, d, err)
+				continue
+			}
+			if distance(coords, want) != 0 {
+				t.Errorf("N=%d Dims=%d: Map(%d) = %v want %v", tc.n, tc.dims, d, coords, want)
+			}
+
+			dPrime, err := s.MapInverse(coords)
+			if err != nil {
+				t.Errorf("N=%d Dims=%d: MapInverse(%v) returned error: %s", tc.n, tc.dims, coords, err)
+			}
+			if !dPrime.IsUint64() || dPrime.Uint64() != uint64(d) {
+				t.Errorf("N=%d Dims=%d: Failed Map(%d) -> MapInverse(%v) -> %s", tc.n, tc.dims, d, coords, dPrime)
+			}
+		}
+	}
+}
+
+// TestHilbertNDBigLarge round trips random values in a space with indexes far larger than a
+// uint, and checks consecutive values are adjacent.
+func TestHilbertNDBigLarge(t *testing.T) {
+	const dims = 6
+	n := 1 << (uintSize / 2)
+	s, err := NewHilbertNDBig(n, dims)
+	if err != nil {
+		t.Fatalf("NewHilbertNDBig(%d, %d) failed: %s", n, dims, err)
+	}
+
+	max := new(big.Int).Lsh(big.NewInt(1), s.bits*dims)
+	one := big.NewInt(1)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		d := new(big.Int).Rand(r, max)
+		if i == 0 {
+			d.Sub(max, big.NewInt(2))
+		}
+
+		coords, err := s.Map(d)
+		if err != nil {
+			t.Fatalf("Map(%s) returned error: %s", d, err)
+		}
+		dPrime, err := s.MapInverse(coords)
+		if err != nil || d.Cmp(dPrime) != 0 {
+			t.Errorf("Failed Map(%s) -> MapInverse(%v) -> (%s, %v)", d, coords, dPrime, err)
+		}
+
+		next, err := s.Map(new(big.Int).Add(d, one))
+		if err != nil {
+			t.Fatalf("Map(%s + 1) returned error: %s", d, err)
+		}
+		if distance(coords, next) != 1 {
+			t.Errorf("Map(%s) = %v is not adjacent to Map(%s + 1) = %v", d, coords, d, next)
+		}
+	}
+
+	if _, err := s.Map(max); err != ErrOutOfRange {
+		t.Errorf("Map(%s) = %q want %q", max, err, ErrOutOfRange)
+	}
+}
+
+func BenchmarkHilbertNDBigMap(b *testing.B) {
+	s, err := NewHilbertNDBig(benchmarkN, 3)
+	if err != nil {
+		b.Fatalf("NewHilbertNDBig(%d, 3) failed: %s", benchmarkN, err)
+	}
+	d := new(big.Int)
+	for i := 0; i < b.N; i++ {
+		for j := int64(0); j < benchmarkN*benchmarkN*benchmarkN; j++ {
+			s.Map(d.SetInt64(j))
+		}
+	}
+}
+
+func BenchmarkHilbertNDBigMapInverse(b *testing.B) {
+	s, err := NewHilbertNDBig(benchmarkN, 3)
+	if err != nil {
+		b.Fatalf("NewHilbertNDBig(%d, 3) failed: %s", benchmarkN, err)
+	}
+	coords := make([]uint, 3)
+	for i := 0; i < b.N; i++ {
+		for x := 0; x < benchmarkN; x++ {
+			for y := 0; y < benchmarkN; y++ {
+				for z := 0; z < benchmarkN; z++ {
+					coords[0], coords[1], coords[2] = uint(x), uint(y), uint(z)
+					s.MapInverse(coords)
+				}
+			}
+		}
+	}
+}
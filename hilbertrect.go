@@ -0,0 +1,173 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+// HilbertRect represents a generalized Hilbert ("gilbert") curve over a rectangle of any size.
+// Implements SpaceFilling interface.
+//
+// The curve is built using Jakub Červený's generalized Hilbert algorithm, which recursively
+// splits the rectangle into two or three smaller rectangles. Every step moves to an adjacent
+// square, except when the longer side is odd and the shorter side is even, in which case the
+// curve may contain a single diagonal step.
+type HilbertRect struct {
+	Width, Height int
+}
+
+// NewHilbertRect returns a generalized Hilbert space of width by height which maps integers to
+// and from the curve. width*height must fit in an int.
+func NewHilbertRect(width, height int) (*HilbertRect, error) {
+	if width <= 0 || height <= 0 {
+		return nil, ErrNotPositive
+	}
+
+	// Test if the size of the space fits in an int
+	if width > maxInt/height {
+		return nil, ErrOverflow
+	}
+
+	return &HilbertRect{
+		Width:  width,
+		Height: height,
+	}, nil
+}
+
+// GetDimensions returns the width and height of the 2D space.
+func (s *HilbertRect) GetDimensions() (int, int) {
+	return s.Width, s.Height
+}
+
+// rect is a rectangle being filled by the generalized Hilbert curve. The curve enters at (x,y),
+// and (ax,ay) and (bx,by) are the major and minor axis vectors, whose lengths are the width
+// and height of the rectangle.
+type rect struct {
+	x, y, ax, ay, bx, by int
+}
+
+// start returns the rect for the whole space.
+func (s *HilbertRect) start() rect {
+	if s.Width >= s.Height {
+		return rect{0, 0, s.Width, 0, 0, s.Height}
+	}
+	return rect{0, 0, 0, s.Height, s.Width, 0}
+}
+
+// size returns the width and height of r.
+func (r rect) size() (w, h int) {
+	return abs(r.ax + r.ay), abs(r.bx + r.by)
+}
+
+// contains returns true if (x,y) is within r.
+func (r rect) contains(x, y int) bool {
+	w, h := r.size()
+	dx, dy := x-r.x, y-r.y
+	i := dx*sign(r.ax) + dy*sign(r.ay)
+	j := dx*sign(r.bx) + dy*sign(r.by)
+	return i >= 0 && i < w && j >= 0 && j < h
+}
+
+// split divides r into the two or three rects the curve passes through, in order.
+// r must be at least two squares wide and high.
+func (r rect) split() []rect {
+	w, h := r.size()
+	dax, day := sign(r.ax), sign(r.ay)
+	dbx, dby := sign(r.bx), sign(r.by)
+
+	ax2, ay2 := floorDiv2(r.ax), floorDiv2(r.ay)
+	bx2, by2 := floorDiv2(r.bx), floorDiv2(r.by)
+	w2, h2 := abs(ax2+ay2), abs(bx2+by2)
+
+	if 2*w > 3*h {
+		// Long case: split in two parts only.
+		if w2%2 != 0 && w > 2 {
+			// Prefer even steps
+			ax2, ay2 = ax2+dax, ay2+day
+		}
+		return []rect{
+			{r.x, r.y, ax2, ay2, r.bx, r.by},
+			{r.x + ax2, r.y + ay2, r.ax - ax2, r.ay - ay2, r.bx, r.by},
+		}
+	}
+
+	// Standard case: one step up, one long horizontal, one step down.
+	if h2%2 != 0 && h > 2 {
+		// Prefer even steps
+		bx2, by2 = bx2+dbx, by2+dby
+	}
+	return []rect{
+		{r.x, r.y, bx2, by2, ax2, ay2},
+		{r.x + bx2, r.y + by2, r.ax, r.ay, r.bx - bx2, r.by - by2},
+		{r.x + (r.ax - dax) + (bx2 - dbx), r.y + (r.ay - day) + (by2 - dby), -bx2, -by2, -(r.ax - ax2), -(r.ay - ay2)},
+	}
+}
+
+// Map transforms a one dimension value, t, in the range [0, width*height-1] to coordinates on
+// the curve in the two-dimension space, where x is within [0,width-1] and y within
+// [0,height-1].
+func (s *HilbertRect) Map(t int) (x, y int, err error) {
+	if t < 0 || t >= s.Width*s.Height {
+		return -1, -1, ErrOutOfRange
+	}
+
+	r := s.start()
+	for {
+		w, h := r.size()
+		if h == 1 {
+			// Trivial row fill
+			return r.x + t*sign(r.ax), r.y + t*sign(r.ay), nil
+		}
+		if w == 1 {
+			// Trivial column fill
+			return r.x + t*sign(r.bx), r.y + t*sign(r.by), nil
+		}
+
+		for _, sub := range r.split() {
+			sw, sh := sub.size()
+			if t < sw*sh {
+				r = sub
+				break
+			}
+			t -= sw * sh
+		}
+	}
+}
+
+// MapInverse transform coordinates on the curve from (x,y) to t.
+func (s *HilbertRect) MapInverse(x, y int) (t int, err error) {
+	if x < 0 || x >= s.Width || y < 0 || y >= s.Height {
+		return -1, ErrOutOfRange
+	}
+
+	r := s.start()
+	for {
+		w, h := r.size()
+		if h == 1 {
+			// Trivial row fill
+			return t + (x-r.x)*sign(r.ax) + (y-r.y)*sign(r.ay), nil
+		}
+		if w == 1 {
+			// Trivial column fill
+			return t + (x-r.x)*sign(r.bx) + (y-r.y)*sign(r.by), nil
+		}
+
+		for _, sub := range r.split() {
+			if sub.contains(x, y) {
+				r = sub
+				break
+			}
+			sw, sh := sub.size()
+			t += sw * sh
+		}
+	}
+}
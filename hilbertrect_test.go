@@ -0,0 +1,212 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"testing"
+)
+
+func TestHilbertRectNewErrors(t *testing.T) {
+	var newTestCases = []struct {
+		width, height int
+		wantErr       error
+	}{
+		{-1, 1, ErrNotPositive},
+		{1, -1, ErrNotPositive},
+		{0, 5, ErrNotPositive},
+		{5, 0, ErrNotPositive},
+		{1 << (uintSize/2 + 1), 1 << (uintSize/2 + 1), ErrOverflow},
+		{2, maxInt/2 + 1, ErrOverflow},
+		{maxInt/3 + 1, 3, ErrOverflow},
+	}
+
+	for _, tc := range newTestCases {
+		s, err := NewHilbertRect(tc.width, tc.height)
+		if s != nil || err != tc.wantErr {
+			t.Errorf("NewHilbertRect(%d, %d) = (%+v, %q) did not fail want (nil, %q)", tc.width, tc.height, s, err, tc.wantErr)
+		}
+	}
+}
+
+func TestHilbertRectRangeErrors(t *testing.T) {
+	s, err := NewHilbertRect(100, 37)
+	if err != nil {
+		t.Fatalf("NewHilbertRect(100, 37) failed: %s", err)
+	}
+
+	for _, d := range []int{-1, 3700} {
+		if _, _, err := s.Map(d); err != ErrOutOfRange {
+			t.Errorf("Map(%d) = %q want %q", d, err, ErrOutOfRange)
+		}
+	}
+
+	var mapInverseRangeTestCases = []struct {
+		x, y int
+	}{
+		{-1, 0}, {0, -1}, {100, 0}, {0, 37},
+	}
+	for _, tc := range mapInverseRangeTestCases {
+		if _, err := s.MapInverse(tc.x, tc.y); err != ErrOutOfRange {
+			t.Errorf("MapInverse(%d, %d) = %q want %q", tc.x, tc.y, err, ErrOutOfRange)
+		}
+	}
+}
+
+func TestHilbertRectGetDimensions(t *testing.T) {
+	s, err := NewHilbertRect(1920, 1080)
+	if err != nil {
+		t.Fatalf("NewHilbertRect(1920, 1080) failed: %s", err)
+	}
+	if w, h := s.GetDimensions(); w != 1920 || h != 1080 {
+		t.Errorf("GetDimensions() = (%d, %d) want (1920, 1080)", w, h)
+	}
+}
+
+// gilbert is a direct port of the reference recursive generator, used to check the iterative
+// Map and MapInverse.
+func gilbert(x, y, ax, ay, bx, by int, emit func(x, y int)) {
+	w, h := abs(ax+ay), abs(bx+by)
+	dax, day := sign(ax), sign(ay)
+	dbx, dby := sign(bx), sign(by)
+
+	if h == 1 {
+		for i := 0; i < w; i++ {
+			emit(x, y)
+			x, y = x+dax, y+day
+		}
+		return
+	}
+	if w == 1 {
+		for i := 0; i < h; i++ {
+			emit(x, y)
+			x, y = x+dbx, y+dby
+		}
+		return
+	}
+
+	ax2, ay2 := floorDiv2(ax), floorDiv2(ay)
+	bx2, by2 := floorDiv2(bx), floorDiv2(by)
+	w2, h2 := abs(ax2+ay2), abs(bx2+by2)
+
+	if 2*w > 3*h {
+		if w2%2 != 0 && w > 2 {
+			ax2, ay2 = ax2+dax, ay2+day
+		}
+		gilbert(x, y, ax2, ay2, bx, by, emit)
+		gilbert(x+ax2, y+ay2, ax-ax2, ay-ay2, bx, by, emit)
+		return
+	}
+
+	if h2%2 != 0 && h > 2 {
+		bx2, by2 = bx2+dbx, by2+dby
+	}
+	gilbert(x, y, bx2, by2, ax2, ay2, emit)
+	gilbert(x+bx2, y+by2, ax, ay, bx-bx2, by-by2, emit)
+	gilbert(x+(ax-dax)+(bx2-dbx), y+(ay-day)+(by2-dby), -bx2, -by2, -(ax - ax2), -(ay - ay2), emit)
+}
+
+func TestHilbertRectAllMapValues(t *testing.T) {
+	var sizes []struct{ width, height int }
+	for w := 1; w <= 20; w++ {
+		for h := 1; h <= 20; h++ {
+			sizes = append(sizes, struct{ width, height int }{w, h})
+		}
+	}
+	sizes = append(sizes, []struct{ width, height int }{{100, 37}, {37, 100}, {64, 64}, {192, 108}}...)
+
+	for _, size := range sizes {
+		s, err := NewHilbertRect(size.width, size.height)
+		if err != nil {
+			t.Fatalf("NewHilbertRect(%d, %d) failed: %s", size.width, size.height, err)
+		}
+
+		r := s.start()
+		d := 0
+		diagonals := 0
+		prevX, prevY := 0, 0
+		gilbert(r.x, r.y, r.ax, r.ay, r.bx, r.by, func(wantX, wantY int) {
+			x, y, err := s.Map(d)
+			if err != nil {
+				t.Errorf("%dx%d: Map(%d) returned error: %s", s.Width, s.Height, d, err)
+			}
+			if x != wantX || y != wantY {
+				t.Errorf("%dx%d: Map(%d) = (%d, %d) want (%d, %d)", s.Width, s.Height, d, x, y, wantX, wantY)
+			}
+
+			dPrime, err := s.MapInverse(x, y)
+			if err != nil {
+				t.Errorf("%dx%d: MapInverse(%d, %d) returned error: %s", s.Width, s.Height, x, y, err)
+			}
+			if d != dPrime {
+				t.Errorf("%dx%d: Failed Map(%d) -> MapInverse(%d, %d) -> %d", s.Width, s.Height, d, x, y, dPrime)
+			}
+
+			if d > 0 {
+				dx, dy := abs(x-prevX), abs(y-prevY)
+				switch {
+				case dx+dy == 1:
+				case dx == 1 && dy == 1:
+					diagonals++
+				default:
+					t.Errorf("%dx%d: Map(%d) = (%d, %d) is not next to Map(%d) = (%d, %d)", s.Width, s.Height, d, x, y, d-1, prevX, prevY)
+				}
+			}
+			prevX, prevY = x, y
+			d++
+		})
+
+		if d != s.Width*s.Height {
+			t.Errorf("%dx%d: reference curve visited %d squares", s.Width, s.Height, d)
+		}
+		// A diagonal step is only needed when the longer side is odd and the shorter is even.
+		long, short := s.Width, s.Height
+		if short > long {
+			long, short = short, long
+		}
+		maxDiagonals := 0
+		if long%2 == 1 && short%2 == 0 {
+			maxDiagonals = 1
+		}
+		if diagonals > maxDiagonals {
+			t.Errorf("%dx%d: curve has %d diagonal steps, want at most %d", s.Width, s.Height, diagonals, maxDiagonals)
+		}
+	}
+}
+
+func BenchmarkHilbertRectMap(b *testing.B) {
+	s, err := NewHilbertRect(1920, 1080)
+	if err != nil {
+		b.Fatalf("NewHilbertRect(1920, 1080) failed: %s", err)
+	}
+	for i := 0; i < b.N; i++ {
+		for d := 0; d < 1024; d++ {
+			s.Map(d * 2025)
+		}
+	}
+}
+
+func BenchmarkHilbertRectMapInverse(b *testing.B) {
+	s, err := NewHilbertRect(1920, 1080)
+	if err != nil {
+		b.Fatalf("NewHilbertRect(1920, 1080) failed: %s", err)
+	}
+	for i := 0; i < b.N; i++ {
+		for x := 0; x < 32; x++ {
+			for y := 0; y < 32; y++ {
+				s.MapInverse(x*60, y*33)
+			}
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import "sync"
+
+// The Hilbert curve can be walked from its largest quadrants down to its smallest as a state
+// machine. The state is the transformation that rotate has applied to the coordinates so far,
+// which is one of four: a combination of whether x and y have been swapped, and whether they
+// have been complemented. As these transformations commute, and each is its own inverse, the
+// state is updated by simply xoring in the transformation for each quadrant.
+//
+// Stepping one bit at a time is no faster than the loops in Map and MapInverse, which are used
+// when there is no table, but the state machine can be unrolled into tables which step through
+// many bits at once. This is similar to the approach described in "Compact Hilbert Indices" by
+// Chris Hamilton (2006).
+const (
+	stateSwap       = 1 << 0
+	stateComplement = 1 << 1
+)
+
+// stateTable holds the precomputed state machine for stepping through bits of each coordinate
+// at a time. Rather than the coordinates, the tables work on their Morton code, where the bits
+// of x and y are interleaved, which turns the Hilbert transform into a conversion between
+// Morton and Hilbert digits. Each digit is the two bits for one quadrant.
+type stateTable struct {
+	bits uint // Bits of each coordinate per step.
+
+	// forward is indexed by state<<(2*bits) | digits, where digits are the next 2*bits bits
+	// of t. Each entry holds the Morton digits, then the next state.
+	forward []uint32
+
+	// inverse is indexed by state<<(2*bits) | digits, where digits are the next 2*bits bits of
+	// the Morton code. Each entry holds the digits of t, then the next state.
+	inverse []uint32
+}
+
+var (
+	stateTablesOnce [9]sync.Once
+	stateTables     [9]*stateTable
+)
+
+// getStateTable returns the table for stepping bits at a time, building it if needed.
+func getStateTable(bits uint) *stateTable {
+	stateTablesOnce[bits].Do(func() {
+		stateTables[bits] = newStateTable(bits)
+	})
+	return stateTables[bits]
+}
+
+// newStateTable builds the table for stepping bits at a time.
+func newStateTable(bits uint) *stateTable {
+	size := 4 << (2 * bits)
+	table := &stateTable{
+		bits:    bits,
+		forward: make([]uint32, size),
+		inverse: make([]uint32, size),
+	}
+
+	for i := range table.forward {
+		start := uint32(i >> (2 * bits))
+		digits := uint32(i) & (1<<(2*bits) - 1)
+
+		// Step through the digits, one quadrant at a time.
+		var morton uint32
+		state := start
+		for j := int(bits) - 1; j >= 0; j-- {
+			d := (digits >> uint(2*j)) & 3
+			rx := d >> 1
+			ry := (d & 1) ^ rx
+
+			bx, by := applyState(state, rx, ry)
+			morton |= (bx<<1 | by) << uint(2*j)
+
+			state = nextState(state, rx, ry)
+		}
+		table.forward[i] = morton | state<<(2*bits)
+		table.inverse[start<<(2*bits)|morton] = digits | state<<(2*bits)
+	}
+	return table
+}
+
+// applyState transforms one bit of each coordinate by the state. As each transformation is
+// its own inverse, this also undoes the transformation.
+func applyState(state, x, y uint32) (uint32, uint32) {
+	if state&stateComplement != 0 {
+		x, y = x^1, y^1
+	}
+	if state&stateSwap != 0 {
+		x, y = y, x
+	}
+	return x, y
+}
+
+// nextState returns the state after moving into the quadrant (rx,ry), matching rotate.
+func nextState(state, rx, ry uint32) uint32 {
+	if ry == 0 {
+		state ^= stateSwap
+		if rx == 1 {
+			state ^= stateComplement
+		}
+	}
+	return state
+}
+
+// NewHilbertTable returns a Hilbert space which maps integers to and from the curve, using
+// precomputed tables to step through bitsPerStep bits of each coordinate at a time. It produces
+// exactly the same curve as NewHilbert, but is faster for large n. bitsPerStep must be 4 or 8.
+// The tables are shared, and take 8KiB for 4 bits, or 2MiB for 8 bits. The coordinates are
+// converted to and from their Morton code, which uses the BMI2 instructions where available.
+//
+// n must be a power of two, and n^2 must fit in an int.
+func NewHilbertTable(n, bitsPerStep int) (*Hilbert, error) {
+	if bitsPerStep != 4 && bitsPerStep != 8 {
+		return nil, ErrBitsPerStep
+	}
+
+	s, err := NewHilbert(n)
+	if err != nil {
+		return nil, err
+	}
+	s.table = getStateTable(uint(bitsPerStep))
+	return s, nil
+}
+
+// startState returns the state, and number of steps, for walking a curve of the given number
+// of levels. When levels is not a multiple of the bits per step, the first step is padded with
+// leading zero bits. Each of these is the lower left quadrant of a larger curve, which swaps x
+// and y, so the starting state must account for them.
+func (table *stateTable) startState(levels uint) (state uint32, steps int) {
+	steps = int((levels + table.bits - 1) / table.bits)
+	padding := uint(steps)*table.bits - levels
+	return uint32(padding & 1), steps
+}
+
+// mapTable is the same as Map, using the state table.
+func (s *Hilbert) mapTable(t uint64) (x, y uint32) {
+	return mortonDecode(s.table.hilbertToMorton(t, uint(s.log2N())))
+}
+
+// mapInverseTable is the same as MapInverse, using the state table.
+func (s *Hilbert) mapInverseTable(x, y uint32) uint64 {
+	return s.table.mortonToHilbert(mortonEncode(x, y), uint(s.log2N()))
+}
+
+// hilbertToMorton converts the Hilbert index t, on a curve of the given number of levels, to
+// the Morton code of the same cell.
+func (table *stateTable) hilbertToMorton(t uint64, levels uint) (morton uint64) {
+	bits := 2 * table.bits
+	mask := uint64(1)<<bits - 1
+
+	state, steps := table.startState(levels)
+	for i := steps - 1; i >= 0; i-- {
+		shift := uint(i) * bits
+		e := table.forward[state<<bits|uint32((t>>shift)&mask)]
+
+		morton |= uint64(e&uint32(mask)) << shift
+		state = e >> bits
+	}
+	return morton
+}
+
+// mortonToHilbert converts the Morton code of a cell, on a curve of the given number of levels,
+// to its Hilbert index.
+func (table *stateTable) mortonToHilbert(morton uint64, levels uint) (t uint64) {
+	bits := 2 * table.bits
+	mask := uint64(1)<<bits - 1
+
+	state, steps := table.startState(levels)
+	for i := steps - 1; i >= 0; i-- {
+		shift := uint(i) * bits
+		e := table.inverse[state<<bits|uint32((morton>>shift)&mask)]
+
+		t |= uint64(e&uint32(mask)) << shift
+		state = e >> bits
+	}
+	return t
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// largestN is the largest Hilbert space supported on this platform.
+const largestN = 1 << (uintSize/2 - 1)
+
+func TestHilbertTableNewErrors(t *testing.T) {
+	var newTestCases = []struct {
+		n, bits int
+		wantErr error
+	}{
+		{0, 4, ErrNotPositive},
+		{3, 8, ErrNotPowerOfTwo},
+		{16, 0, ErrBitsPerStep},
+		{16, 2, ErrBitsPerStep},
+		{16, 16, ErrBitsPerStep},
+	}
+
+	for _, tc := range newTestCases {
+		s, err := NewHilbertTable(tc.n, tc.bits)
+		if s != nil || err != tc.wantErr {
+			t.Errorf("NewHilbertTable(%d, %d) = (%+v, %q) did not fail want (nil, %q)", tc.n, tc.bits, s, err, tc.wantErr)
+		}
+	}
+}
+
+// TestHilbertTableAllMapValues checks every value maps the same with and without the tables.
+func TestHilbertTableAllMapValues(t *testing.T) {
+	for _, bits := range []int{4, 8} {
+		for n := 1; n <= 1024; n *= 2 {
+			h, err := NewHilbert(n)
+			if err != nil {
+				t.Fatalf("NewHilbert(%d) failed: %s", n, err)
+			}
+			s, err := NewHilbertTable(n, bits)
+			if err != nil {
+				t.Fatalf("NewHilbertTable(%d, %d) failed: %s", n, bits, err)
+			}
+
+			for d := 0; d < n*n; d++ {
+				wantX, wantY, _ := h.Map(d)
+				x, y, err := s.Map(d)
+				if err != nil {
+					t.Fatalf("N=%d bits=%d: Map(%d) returned error: %s", n, bits, d, err)
+				}
+				if x != wantX || y != wantY {
+					t.Fatalf("N=%d bits=%d: Map(%d) = (%d, %d) want (%d, %d)", n, bits, d, x, y, wantX, wantY)
+				}
+
+				dPrime, err := s.MapInverse(x, y)
+				if err != nil {
+					t.Fatalf("N=%d bits=%d: MapInverse(%d, %d) returned error: %s", n, bits, x, y, err)
+				}
+				if d != dPrime {
+					t.Fatalf("N=%d bits=%d: Failed Map(%d) -> MapInverse(%d, %d) -> %d", n, bits, d, x, y, dPrime)
+				}
+			}
+		}
+	}
+}
+
+// TestHilbertTableLarge checks random values map the same with and without the tables, on
+// every size up to the largest.
+func TestHilbertTableLarge(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, bits := range []int{4, 8} {
+		for n := 2048; n <= largestN; n *= 2 {
+			h, _ := NewHilbert(n)
+			s, err := NewHilbertTable(n, bits)
+			if err != nil {
+				t.Fatalf("NewHilbertTable(%d, %d) failed: %s", n, bits, err)
+			}
+
+			for i := 0; i < 1000; i++ {
+				d := int(r.Int63n(int64(n) * int64(n)))
+				wantX, wantY, _ := h.Map(d)
+				x, y, _ := s.Map(d)
+				if x != wantX || y != wantY {
+					t.Fatalf("N=%d bits=%d: Map(%d) = (%d, %d) want (%d, %d)", n, bits, d, x, y, wantX, wantY)
+				}
+				if dPrime, _ := s.MapInverse(x, y); d != dPrime {
+					t.Fatalf("N=%d bits=%d: Failed Map(%d) -> MapInverse(%d, %d) -> %d", n, bits, d, x, y, dPrime)
+				}
+			}
+		}
+	}
+}
+
+// TestHilbertTableChangeN checks a table space follows changes to its exported N.
+func TestHilbertTableChangeN(t *testing.T) {
+	s, err := NewHilbertTable(16, 4)
+	if err != nil {
+		t.Fatalf("NewHilbertTable(16, 4) failed: %s", err)
+	}
+	s.N = 64
+	h, _ := NewHilbert(64)
+
+	for d := 0; d < 64*64; d++ {
+		wantX, wantY, _ := h.Map(d)
+		if x, y, _ := s.Map(d); x != wantX || y != wantY {
+			t.Fatalf("Map(%d) after changing N = (%d, %d) want (%d, %d)", d, x, y, wantX, wantY)
+		}
+		if dPrime, _ := s.MapInverse(wantX, wantY); dPrime != d {
+			t.Fatalf("MapInverse(%d, %d) after changing N = %d want %d", wantX, wantY, dPrime, d)
+		}
+	}
+}
+
+func TestHilbertTableMapBatch(t *testing.T) {
+	h, _ := NewHilbert(64)
+	s, err := NewHilbertTable(64, 8)
+	if err != nil {
+		t.Fatalf("NewHilbertTable(64, 8) failed: %s", err)
+	}
+
+	ts := make([]uint64, 64*64)
+	for d := range ts {
+		ts[d] = uint64(d)
+	}
+	xs, ys := make([]uint32, len(ts)), make([]uint32, len(ts))
+	if err := s.MapBatch(ts, xs, ys); err != nil {
+		t.Fatalf("MapBatch returned error: %s", err)
+	}
+	for d := range ts {
+		x, y, _ := h.Map(d)
+		if xs[d] != uint32(x) || ys[d] != uint32(y) {
+			t.Errorf("MapBatch(%d) = (%d, %d) want (%d, %d)", d, xs[d], ys[d], x, y)
+		}
+	}
+
+	tsPrime := make([]uint64, len(ts))
+	if err := s.MapInverseBatch(xs, ys, tsPrime); err != nil {
+		t.Fatalf("MapInverseBatch returned error: %s", err)
+	}
+	for d := range ts {
+		if tsPrime[d] != ts[d] {
+			t.Errorf("MapInverseBatch(%d, %d) = %d want %d", xs[d], ys[d], tsPrime[d], ts[d])
+		}
+	}
+}
+
+// benchmarkHilbertTable maps, and inverse maps, 1024 random values on a curve of width n.
+func benchmarkHilbertTable(b *testing.B, n, bits int, inverse bool) {
+	var s *Hilbert
+	var err error
+	if bits == 0 {
+		s, err = NewHilbert(n)
+	} else {
+		s, err = NewHilbertTable(n, bits)
+	}
+	if err != nil {
+		b.Fatalf("Failed to create hilbert space: %s", err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	ds := make([]int, 1024)
+	xs, ys := make([]int, len(ds)), make([]int, len(ds))
+	for i := range ds {
+		ds[i] = int(r.Int63n(int64(n) * int64(n)))
+		xs[i], ys[i], _ = s.Map(ds[i])
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range ds {
+			if inverse {
+				s.MapInverse(xs[j], ys[j])
+			} else {
+				s.Map(ds[j])
+			}
+		}
+	}
+}
+
+func BenchmarkMap16(b *testing.B)       { benchmarkHilbertTable(b, 1<<16, 0, false) }
+func BenchmarkMap16Table4(b *testing.B) { benchmarkHilbertTable(b, 1<<16, 4, false) }
+func BenchmarkMap16Table8(b *testing.B) { benchmarkHilbertTable(b, 1<<16, 8, false) }
+func BenchmarkMap31(b *testing.B)       { benchmarkHilbertTable(b, largestN, 0, false) }
+func BenchmarkMap31Table4(b *testing.B) { benchmarkHilbertTable(b, largestN, 4, false) }
+func BenchmarkMap31Table8(b *testing.B) { benchmarkHilbertTable(b, largestN, 8, false) }
+
+func BenchmarkMapInverse16(b *testing.B)       { benchmarkHilbertTable(b, 1<<16, 0, true) }
+func BenchmarkMapInverse16Table4(b *testing.B) { benchmarkHilbertTable(b, 1<<16, 4, true) }
+func BenchmarkMapInverse16Table8(b *testing.B) { benchmarkHilbertTable(b, 1<<16, 8, true) }
+func BenchmarkMapInverse31(b *testing.B)       { benchmarkHilbertTable(b, largestN, 0, true) }
+func BenchmarkMapInverse31Table4(b *testing.B) { benchmarkHilbertTable(b, largestN, 4, true) }
+func BenchmarkMapInverse31Table8(b *testing.B) { benchmarkHilbertTable(b, largestN, 8, true) }
+
+// TestMortonHilbertTables checks the conversion between Morton and Hilbert indexes, with both
+// the pure Go and BMI2 Morton codes, agrees with Hilbert.
+func TestMortonHilbertTables(t *testing.T) {
+	h, _ := NewHilbert(256)
+	for _, bits := range []uint{4, 8} {
+		table := getStateTable(bits)
+		for x := uint32(0); x < 256; x++ {
+			for y := uint32(0); y < 256; y++ {
+				want, _ := h.MapInverse(int(x), int(y))
+
+				morton := mortonEncodeGeneric(x, y)
+				if useBMI2 && mortonEncodeBMI2(x, y) != morton {
+					t.Fatalf("mortonEncodeBMI2(%d, %d) = %d want %d", x, y, mortonEncodeBMI2(x, y), morton)
+				}
+
+				if got := table.mortonToHilbert(morton, 8); got != uint64(want) {
+					t.Fatalf("bits=%d: mortonToHilbert(%d) = %d want %d", bits, morton, got, want)
+				}
+				if got := table.hilbertToMorton(uint64(want), 8); got != morton {
+					t.Fatalf("bits=%d: hilbertToMorton(%d) = %d want %d", bits, want, got, morton)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+// +build go1.23
+
+package hilbert
+
+import "iter"
+
+// Points returns an iterator over every point on the curve, in order, with its index.
+//
+//	for t, p := range hilbert.Points(curve) {
+//		...
+//	}
+//
+// For Hilbert and Peano curves the iterator walks the curve recursively, so each step costs
+// O(1) on average, instead of the O(log N) of calling Map for every index.
+func Points(curve SpaceFilling) iter.Seq2[int, Point] {
+	w, h := curve.GetDimensions()
+	return PointsRange(curve, 0, w*h)
+}
+
+// PointsRange returns an iterator over the points on the curve with indexes in [from, to), in
+// order, with their indexes. The range is clipped to the curve.
+func PointsRange(curve SpaceFilling, from, to int) iter.Seq2[int, Point] {
+	w, h := curve.GetDimensions()
+	if from < 0 {
+		from = 0
+	}
+	if to > w*h {
+		to = w * h
+	}
+
+	return func(yield func(int, Point) bool) {
+		if from >= to {
+			return
+		}
+		wk := &walker{from: from, to: to, yield: yield}
+
+		switch s := curve.(type) {
+		case *Hilbert:
+			wk.hilbert(0, s.N, 0, 0, 1, 0, 0, 1)
+		case *Peano:
+			wk.peano(0, s.N, 0, 0, 1, 0, 0, 1)
+		default:
+			wk.generic(curve)
+		}
+	}
+}
+
+// walker yields the points of a curve with indexes in [from, to).
+//
+// The recursive walks track each square of the curve as the position of its corner, and the
+// directions its local x and y axes point in, (ax,ay) and (bx,by). The point at (x,y) within the
+// square is at corner + x*(ax,ay) + y*(bx,by). They return false once yield does.
+type walker struct {
+	from, to int
+	yield    func(int, Point) bool
+}
+
+// hilbert walks the square of width n, whose indexes start at t, in the same way as Hilbert.
+func (wk *walker) hilbert(t, n, ox, oy, ax, ay, bx, by int) bool {
+	if t >= wk.to || t+n*n <= wk.from {
+		return true
+	}
+	if n == 1 {
+		return wk.yield(t, Point{ox, oy})
+	}
+
+	// The quadrants in the order of the curve, with the rotations Map applies. The first is
+	// transposed, and the last is transposed about the other diagonal.
+	h := n / 2
+	s := h * h
+	return wk.hilbert(t, h, ox, oy, bx, by, ax, ay) &&
+		wk.hilbert(t+s, h, ox+h*bx, oy+h*by, ax, ay, bx, by) &&
+		wk.hilbert(t+2*s, h, ox+h*(ax+bx), oy+h*(ay+by), ax, ay, bx, by) &&
+		wk.hilbert(t+3*s, h, ox+(2*h-1)*ax+(h-1)*bx, oy+(2*h-1)*ay+(h-1)*by, -bx, -by, -ax, -ay)
+}
+
+// peano walks the square of width n, whose indexes start at t, in the same way as Peano.
+func (wk *walker) peano(t, n, ox, oy, ax, ay, bx, by int) bool {
+	if t >= wk.to || t+n*n <= wk.from {
+		return true
+	}
+	if n == 1 {
+		return wk.yield(t, Point{ox, oy})
+	}
+
+	i := n / 3
+	for d := 0; d < 9; d++ {
+		px, py := int(peanoX[d])*i, int(peanoY[d])*i
+		cx, cy := ox+px*ax+py*bx, oy+px*ay+py*by
+		cax, cay, cbx, cby := ax, ay, bx, by
+		if peanoFlipX[d] {
+			cx, cy = cx+(i-1)*ax, cy+(i-1)*ay
+			cax, cay = -ax, -ay
+		}
+		if peanoFlipY[d] {
+			cx, cy = cx+(i-1)*bx, cy+(i-1)*by
+			cbx, cby = -bx, -by
+		}
+		if !wk.peano(t+d*i*i, i, cx, cy, cax, cay, cbx, cby) {
+			return false
+		}
+	}
+	return true
+}
+
+// generic walks any curve by mapping each index, in batches if the curve supports them. It
+// stops early if Map fails.
+func (wk *walker) generic(curve SpaceFilling) {
+	batch, ok := curve.(BatchSpaceFilling)
+	if !ok {
+		for t := wk.from; t < wk.to; t++ {
+			x, y, err := curve.Map(t)
+			if err != nil || !wk.yield(t, Point{x, y}) {
+				return
+			}
+		}
+		return
+	}
+
+	const batchSize = 256
+	ts := make([]uint64, batchSize)
+	xs := make([]uint32, batchSize)
+	ys := make([]uint32, batchSize)
+	for start := wk.from; start < wk.to; start += batchSize {
+		n := wk.to - start
+		if n > batchSize {
+			n = batchSize
+		}
+		for i := range ts[:n] {
+			ts[i] = uint64(start + i)
+		}
+		if batch.MapBatch(ts[:n], xs[:n], ys[:n]) != nil {
+			return
+		}
+		for i := 0; i < n; i++ {
+			if !wk.yield(start+i, Point{int(xs[i]), int(ys[i])}) {
+				return
+			}
+		}
+	}
+}
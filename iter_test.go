@@ -0,0 +1,151 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+// +build go1.23
+
+package hilbert
+
+import "testing"
+
+func iterTestCurves(t testing.TB) []SpaceFilling {
+	var curves []SpaceFilling
+	add := func(s SpaceFilling, err error) {
+		if err != nil {
+			t.Fatalf("creating curve failed: %s", err)
+		}
+		curves = append(curves, s)
+	}
+
+	for _, n := range []int{1, 2, 4, 32} {
+		add(NewHilbert(n))
+		add(NewMorton(n))
+	}
+	for _, n := range []int{1, 3, 9, 27} {
+		add(NewPeano(n))
+	}
+	add(NewHilbertTable(64, 4))
+	add(NewMoore(16))
+	add(NewHilbertRect(7, 5))
+	return curves
+}
+
+func TestPoints(t *testing.T) {
+	for _, curve := range iterTestCurves(t) {
+		w, h := curve.GetDimensions()
+		next := 0
+		for i, p := range Points(curve) {
+			if i != next {
+				t.Fatalf("%T %dx%d: got index %d want %d", curve, w, h, i, next)
+			}
+			x, y, _ := curve.Map(i)
+			if p != (Point{x, y}) {
+				t.Errorf("%T %dx%d: Points gave %d -> %v want (%d,%d)", curve, w, h, i, p, x, y)
+			}
+			next++
+		}
+		if next != w*h {
+			t.Errorf("%T %dx%d: Points gave %d points want %d", curve, w, h, next, w*h)
+		}
+	}
+}
+
+func TestPointsRange(t *testing.T) {
+	var testCases = []struct {
+		from, to          int
+		wantFrom, wantEnd int // want indexes [wantFrom, wantEnd)
+	}{
+		{0, 10, 0, 10},
+		{5, 6, 5, 6},
+		{17, 200, 17, 200},
+		{-5, 3, 0, 3},
+		{1000, 5000, 1000, 5000},
+		{10, 10, 0, 0},
+		{10, 5, 0, 0},
+	}
+
+	for _, curve := range iterTestCurves(t) {
+		w, h := curve.GetDimensions()
+		for _, tc := range testCases {
+			wantEnd := tc.wantEnd
+			if wantEnd > w*h {
+				wantEnd = w * h
+			}
+			next := tc.wantFrom
+			for i, p := range PointsRange(curve, tc.from, tc.to) {
+				if i != next {
+					t.Fatalf("%T %dx%d: PointsRange(%d, %d) gave index %d want %d", curve, w, h, tc.from, tc.to, i, next)
+				}
+				x, y, _ := curve.Map(i)
+				if p != (Point{x, y}) {
+					t.Errorf("%T %dx%d: PointsRange gave %d -> %v want (%d,%d)", curve, w, h, i, p, x, y)
+				}
+				next++
+			}
+			if tc.wantFrom < wantEnd && next != wantEnd {
+				t.Errorf("%T %dx%d: PointsRange(%d, %d) stopped at %d want %d", curve, w, h, tc.from, tc.to, next, wantEnd)
+			}
+		}
+	}
+}
+
+func TestPointsBreak(t *testing.T) {
+	for _, curve := range iterTestCurves(t) {
+		count := 0
+		for range Points(curve) {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+		w, h := curve.GetDimensions()
+		if want := min(3, w*h); count != want {
+			t.Errorf("%T: loop ran %d times want %d", curve, count, want)
+		}
+	}
+}
+
+func BenchmarkPointsHilbert(b *testing.B) {
+	s, _ := NewHilbert(1024)
+	for i := 0; i < b.N; i++ {
+		for range Points(s) {
+		}
+	}
+}
+
+func BenchmarkMapLoopHilbert(b *testing.B) {
+	s, _ := NewHilbert(1024)
+	for i := 0; i < b.N; i++ {
+		for t := 0; t < s.N*s.N; t++ {
+			s.Map(t)
+		}
+	}
+}
+
+func BenchmarkPointsPeano(b *testing.B) {
+	s, _ := NewPeano(729)
+	for i := 0; i < b.N; i++ {
+		for range Points(s) {
+		}
+	}
+}
+
+func BenchmarkMapLoopPeano(b *testing.B) {
+	s, _ := NewPeano(729)
+	for i := 0; i < b.N; i++ {
+		for t := 0; t < s.N*s.N; t++ {
+			s.Map(t)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+// orientations are the eight ways a curve can be laid out within a square. Each is a pair of
+// axes (ax,ay) and (bx,by), which are each a unit vector along x or y, possibly negated, and
+// the curve runs along the first axis where an unrotated curve runs along x.
+var orientations = [8][4]int{
+	{1, 0, 0, 1}, {0, 1, 1, 0}, {-1, 0, 0, 1}, {0, 1, -1, 0},
+	{1, 0, 0, -1}, {0, -1, 1, 0}, {-1, 0, 0, -1}, {0, -1, -1, 0},
+}
+
+// orientation returns the index of the axes in orientations.
+func orientation(a [4]int) int {
+	for i, o := range orientations {
+		if o == a {
+			return i
+		}
+	}
+	panic("assertion failure: axes are not an orientation")
+}
+
+// curveLayout describes how a curve divides a square into a grid of smaller squares, and the
+// order it visits them in.
+type curveLayout struct {
+	base int // Number of squares.
+
+	// squares holds, for each orientation of the square being divided, each of the smaller
+	// squares in the order the curve visits them.
+	squares [len(orientations)][]layoutSquare
+}
+
+// layoutSquare is one of the smaller squares of a curveLayout.
+type layoutSquare struct {
+	col, row int // Position in the grid.
+	orient   int // Orientation of the curve within it.
+	dx, dy   int // Move from the last point of this square to the first point of the next.
+}
+
+// newCurveLayout returns the layout of a curve which divides a square into a width by width
+// grid. Laid out along x and y, the curve visits the squares at (x[i],y[i]) in turn, and lays
+// out the curve within each along axes[i], in terms of the axes of the square it is within.
+func newCurveLayout(width int, x, y []int, axes [][4]int) *curveLayout {
+	l := &curveLayout{base: len(x)}
+	for o, a := range orientations {
+		squares := make([]layoutSquare, len(x))
+		for d := range squares {
+			sq := &squares[d]
+
+			// The square's position along each axis, counting from the far end if the axis
+			// is negated.
+			sq.col = x[d]*a[0] + y[d]*a[2]
+			if a[0]+a[2] < 0 {
+				sq.col += width - 1
+			}
+			sq.row = x[d]*a[1] + y[d]*a[3]
+			if a[1]+a[3] < 0 {
+				sq.row += width - 1
+			}
+
+			c := axes[d]
+			sq.orient = orientation([4]int{
+				c[0]*a[0] + c[1]*a[2], c[0]*a[1] + c[1]*a[3],
+				c[2]*a[0] + c[3]*a[2], c[2]*a[1] + c[3]*a[3],
+			})
+
+			if d+1 < len(x) {
+				lx, ly := x[d+1]-x[d], y[d+1]-y[d]
+				sq.dx, sq.dy = lx*a[0]+ly*a[2], lx*a[1]+ly*a[3]
+			}
+		}
+		l.squares[o] = squares
+	}
+	return l
+}
+
+var hilbertLayout = newCurveLayout(2,
+	[]int{0, 0, 1, 1},
+	[]int{0, 1, 1, 0},
+	[][4]int{
+		{0, 1, 1, 0}, // transpose
+		{1, 0, 0, 1},
+		{1, 0, 0, 1},
+		{0, -1, -1, 0}, // transpose about the other diagonal
+	})
+
+var mortonLayout = newCurveLayout(2,
+	[]int{0, 0, 1, 1},
+	[]int{0, 1, 0, 1},
+	[][4]int{{1, 0, 0, 1}, {1, 0, 0, 1}, {1, 0, 0, 1}, {1, 0, 0, 1}})
+
+// mooreLayout is the first level of a Moore curve, below which it is a Hilbert curve.
+var mooreLayout = newCurveLayout(2,
+	[]int{0, 0, 1, 1},
+	[]int{0, 1, 1, 0},
+	[][4]int{
+		{0, 1, -1, 0}, // run up the left quadrants
+		{0, 1, -1, 0},
+		{0, -1, 1, 0}, // and down the right quadrants
+		{0, -1, 1, 0},
+	})
+
+var peanoLayout = newCurveLayout(3,
+	[]int{0, 0, 0, 1, 1, 1, 2, 2, 2},
+	[]int{0, 1, 2, 2, 1, 0, 0, 1, 2},
+	[][4]int{
+		{1, 0, 0, 1},
+		{-1, 0, 0, 1}, // flip x
+		{1, 0, 0, 1},
+		{1, 0, 0, -1}, // flip y
+		{-1, 0, 0, -1},
+		{1, 0, 0, -1},
+		{1, 0, 0, 1},
+		{-1, 0, 0, 1},
+		{1, 0, 0, 1},
+	})
@@ -0,0 +1,131 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"math/rand"
+
+	"github.com/google/hilbert"
+)
+
+// Clustering holds the number of clusters, runs of consecutive indexes on the curve, that box
+// queries are split into. Each cluster is a separate range scan over keys sorted by their
+// index, so fewer is better.
+type Clustering struct {
+	Average float64
+	Max     int
+}
+
+// ComputeClustering returns the number of clusters in queries boxes of width and height size,
+// placed at random within the space. The box is clipped to the space if it is larger.
+func ComputeClustering(curve hilbert.SpaceFilling, queries, size int, rng *rand.Rand) (Clustering, error) {
+	l, err := newLayout(curve)
+	if err != nil {
+		return Clustering{}, err
+	}
+	return l.clustering(queries, size, rng)
+}
+
+// NeighborHistogram returns a histogram of the distances along the curve between each pair of
+// cells which share an edge. Bucket i counts the pairs whose indexes differ by at least 2^i
+// and less than 2^(i+1), so bucket 0 counts the pairs next to each other on the curve.
+func NeighborHistogram(curve hilbert.SpaceFilling) ([]int, error) {
+	l, err := newLayout(curve)
+	if err != nil {
+		return nil, err
+	}
+	return l.neighborHistogram(), nil
+}
+
+func (l *layout) clustering(queries, size int, rng *rand.Rand) (Clustering, error) {
+	if queries <= 0 || size <= 0 {
+		return Clustering{}, ErrInvalidQuery
+	}
+	w, h := size, size
+	if w > l.w {
+		w = l.w
+	}
+	if h > l.h {
+		h = l.h
+	}
+
+	var c Clustering
+	total := 0
+	for q := 0; q < queries; q++ {
+		x := rng.Intn(l.w - w + 1)
+		y := rng.Intn(l.h - h + 1)
+		n := l.clusters(x, y, x+w-1, y+h-1)
+		if n > c.Max {
+			c.Max = n
+		}
+		total += n
+	}
+	c.Average = float64(total) / float64(queries)
+	return c, nil
+}
+
+// clusters returns the number of clusters in the box with corners (xmin,ymin) and (xmax,ymax)
+// inclusive. Each cluster starts with a cell whose predecessor on the curve is outside the box.
+func (l *layout) clusters(xmin, ymin, xmax, ymax int) int {
+	n := 0
+	for y := ymin; y <= ymax; y++ {
+		for x := xmin; x <= xmax; x++ {
+			t := l.index(x, y)
+			if t == 0 {
+				n++
+				continue
+			}
+			px, py := l.position(t - 1)
+			if px < xmin || px > xmax || py < ymin || py > ymax {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func (l *layout) neighborHistogram() []int {
+	var histogram []int
+	add := func(t1, t2 int) {
+		i := bucket(abs(t1 - t2))
+		for len(histogram) <= i {
+			histogram = append(histogram, 0)
+		}
+		histogram[i]++
+	}
+
+	for y := 0; y < l.h; y++ {
+		for x := 0; x < l.w; x++ {
+			t := l.index(x, y)
+			if x+1 < l.w {
+				add(t, l.index(x+1, y))
+			}
+			if y+1 < l.h {
+				add(t, l.index(x, y+1))
+			}
+		}
+	}
+	return histogram
+}
+
+// bucket returns floor(log2(d)) for d > 0.
+func bucket(d int) int {
+	i := 0
+	for d > 1 {
+		d >>= 1
+		i++
+	}
+	return i
+}
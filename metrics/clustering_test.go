@@ -0,0 +1,147 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/hilbert"
+)
+
+// bruteClusters returns the number of clusters in the box by sorting the indexes within it.
+func bruteClusters(curve hilbert.SpaceFilling, xmin, ymin, xmax, ymax int) int {
+	w, h := curve.GetDimensions()
+	in := make([]bool, w*h)
+	for x := xmin; x <= xmax; x++ {
+		for y := ymin; y <= ymax; y++ {
+			t, _ := curve.MapInverse(x, y)
+			in[t] = true
+		}
+	}
+	n := 0
+	for t := range in {
+		if in[t] && (t == 0 || !in[t-1]) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestClusters(t *testing.T) {
+	h, _ := hilbert.NewHilbert(16)
+	p, _ := hilbert.NewPeano(27)
+	m, _ := hilbert.NewMorton(16)
+	r, _ := hilbert.NewHilbertRect(20, 12)
+
+	rng := rand.New(rand.NewSource(1))
+	for _, curve := range []hilbert.SpaceFilling{h, p, m, r} {
+		l, err := newLayout(curve)
+		if err != nil {
+			t.Fatalf("newLayout(%T) failed: %s", curve, err)
+		}
+		for i := 0; i < 200; i++ {
+			xmin, xmax := rng.Intn(l.w), rng.Intn(l.w)
+			ymin, ymax := rng.Intn(l.h), rng.Intn(l.h)
+			if xmin > xmax {
+				xmin, xmax = xmax, xmin
+			}
+			if ymin > ymax {
+				ymin, ymax = ymax, ymin
+			}
+
+			got := l.clusters(xmin, ymin, xmax, ymax)
+			want := bruteClusters(curve, xmin, ymin, xmax, ymax)
+			if got != want {
+				t.Errorf("%T clusters(%d,%d,%d,%d) = %d want %d", curve, xmin, ymin, xmax, ymax, got, want)
+			}
+		}
+
+		// The whole space is one cluster.
+		if got := l.clusters(0, 0, l.w-1, l.h-1); got != 1 {
+			t.Errorf("%T clusters(whole space) = %d want 1", curve, got)
+		}
+	}
+}
+
+func TestComputeClustering(t *testing.T) {
+	h, _ := hilbert.NewHilbert(32)
+	m, _ := hilbert.NewMorton(32)
+
+	hc, err := ComputeClustering(h, 500, 8, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("ComputeClustering(Hilbert) failed: %s", err)
+	}
+	mc, err := ComputeClustering(m, 500, 8, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("ComputeClustering(Morton) failed: %s", err)
+	}
+
+	if hc.Average < 1 || float64(hc.Max) < hc.Average {
+		t.Errorf("ComputeClustering(Hilbert) = %v is inconsistent", hc)
+	}
+	if hc.Average >= mc.Average {
+		t.Errorf("ComputeClustering(Hilbert) = %v want fewer clusters than Morton %v", hc, mc)
+	}
+
+	// A box larger than the space is clipped to it, and so is one cluster.
+	whole, err := ComputeClustering(h, 3, 100, rand.New(rand.NewSource(1)))
+	if err != nil || whole.Average != 1 || whole.Max != 1 {
+		t.Errorf("ComputeClustering(Hilbert, size 100) = %v, %v want 1 cluster", whole, err)
+	}
+
+	for _, bad := range [][2]int{{0, 8}, {10, 0}, {-1, 8}} {
+		if _, err := ComputeClustering(h, bad[0], bad[1], rand.New(rand.NewSource(1))); err != ErrInvalidQuery {
+			t.Errorf("ComputeClustering(%d, %d) err = %v want %v", bad[0], bad[1], err, ErrInvalidQuery)
+		}
+	}
+}
+
+func TestNeighborHistogram(t *testing.T) {
+	h, _ := hilbert.NewHilbert(16)
+	p, _ := hilbert.NewPeano(9)
+	m, _ := hilbert.NewMorton(16)
+
+	for _, curve := range []hilbert.SpaceFilling{h, p, m} {
+		histogram, err := NeighborHistogram(curve)
+		if err != nil {
+			t.Fatalf("NeighborHistogram(%T) failed: %s", curve, err)
+		}
+		n, _ := curve.GetDimensions()
+		total := 0
+		for _, c := range histogram {
+			total += c
+		}
+		if want := 2 * n * (n - 1); total != want {
+			t.Errorf("NeighborHistogram(%T) counts %d pairs want %d", curve, total, want)
+		}
+
+		// Every step along a continuous curve is between neighbours.
+		if _, ok := curve.(*hilbert.Morton); !ok && histogram[0] != n*n-1 {
+			t.Errorf("NeighborHistogram(%T)[0] = %d want %d", curve, histogram[0], n*n-1)
+		}
+	}
+}
+
+func TestBucket(t *testing.T) {
+	var testCases = []struct{ d, want int }{
+		{1, 0}, {2, 1}, {3, 1}, {4, 2}, {7, 2}, {8, 3}, {1023, 9}, {1024, 10},
+	}
+	for _, tc := range testCases {
+		if got := bucket(tc.d); got != tc.want {
+			t.Errorf("bucket(%d) = %d want %d", tc.d, got, tc.want)
+		}
+	}
+}
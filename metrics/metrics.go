@@ -0,0 +1,203 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics measures how well space-filling curves preserve locality, for comparing
+// curves and choosing between them.
+//
+// The measures are those of Haverkort and van Walderveen, "Locality and bounding-box quality of
+// two-dimensional space-filling curves" (2010), taken over the cells of a finite curve: the
+// worst-case dilation and bounding-box ratios of sections of the curve, the number of clusters
+// a box query is split into, and how far apart neighbouring cells are along the curve.
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/hilbert"
+)
+
+// Errors returned when measuring a curve.
+var (
+	ErrInvalidQuery = errors.New("number and size of queries must be greater than zero")
+	ErrTooLarge     = errors.New("curve is too large to measure")
+	ErrEmpty        = errors.New("curve has no cells to measure")
+)
+
+// maxCells is the largest number of cells a curve may have. The section measures take time
+// quadratic in the number of cells, so larger curves take too long to measure.
+const maxCells = 1 << 16
+
+// Options controls how a curve is measured by Measure.
+type Options struct {
+	Queries   int   // Number of random box queries, default 1000.
+	QuerySize int   // Width and height of each query, default a quarter of the space.
+	Seed      int64 // Seed for the positions of the queries.
+}
+
+// Report holds all the measures of a curve.
+type Report struct {
+	Name          string // Name of the curve, such as "Hilbert".
+	Width, Height int
+
+	Dilation    Dilation
+	BoundingBox BoundingBox
+	Clustering  Clustering
+	Histogram   []int // See NeighborHistogram.
+}
+
+// Measure returns a report of all the measures of the curve.
+func Measure(curve hilbert.SpaceFilling, opts Options) (*Report, error) {
+	l, err := newLayout(curve)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Queries == 0 {
+		opts.Queries = 1000
+	}
+	if opts.QuerySize == 0 {
+		opts.QuerySize = l.w / 4
+		if l.h < l.w {
+			opts.QuerySize = l.h / 4
+		}
+		if opts.QuerySize < 1 {
+			opts.QuerySize = 1
+		}
+	}
+
+	r := &Report{
+		Name:   curveName(curve),
+		Width:  l.w,
+		Height: l.h,
+
+		Dilation:    l.dilation(),
+		BoundingBox: l.boundingBox(),
+		Histogram:   l.neighborHistogram(),
+	}
+	r.Clustering, err = l.clustering(opts.Queries, opts.QuerySize, rand.New(rand.NewSource(opts.Seed)))
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// curveName returns the name of the curve's type, without the package.
+func curveName(curve hilbert.SpaceFilling) string {
+	name := fmt.Sprintf("%T", curve)
+	name = strings.TrimPrefix(name, "*")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// WriteTable writes the reports as a table, one row for each report.
+//
+// Besides the measures in the report, the "adjacent" column is the percentage of neighbouring
+// cells which are next to each other on the curve, and the "p90" column is the distance along
+// the curve which 90% of neighbouring cells are within, rounded down to a power of two.
+func WriteTable(w io.Writer, reports []*Report) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "curve\tsize\tWL∞\tWL2\tWL1\tworst bbox\tavg bbox\tavg clusters\tmax clusters\tadjacent\tp90\t")
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%s\t%dx%d\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.2f\t%d\t%.1f%%\t%d\t\n",
+			r.Name, r.Width, r.Height,
+			r.Dilation.WLInf, r.Dilation.WL2, r.Dilation.WL1,
+			r.BoundingBox.Worst, r.BoundingBox.Average,
+			r.Clustering.Average, r.Clustering.Max,
+			100*adjacentFraction(r.Histogram), histogramPercentile(r.Histogram, 0.9))
+	}
+	return tw.Flush()
+}
+
+// adjacentFraction returns the fraction of the neighbouring cells counted in the histogram
+// which are next to each other on the curve.
+func adjacentFraction(histogram []int) float64 {
+	total := 0
+	for _, c := range histogram {
+		total += c
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(histogram[0]) / float64(total)
+}
+
+// histogramPercentile returns the lower bound of the bucket holding the p'th fraction of the
+// histogram.
+func histogramPercentile(histogram []int, p float64) int {
+	total := 0
+	for _, c := range histogram {
+		total += c
+	}
+	seen := 0
+	for i, c := range histogram {
+		seen += c
+		if float64(seen) >= p*float64(total) && total > 0 {
+			return 1 << uint(i)
+		}
+	}
+	return 0
+}
+
+// layout holds the position of every cell on a curve, and the index of every position.
+type layout struct {
+	w, h   int
+	xs, ys []uint32 // Position of each index.
+	ts     []int    // Index of each position, at y*w+x.
+}
+
+func newLayout(curve hilbert.SpaceFilling) (*layout, error) {
+	w, h := curve.GetDimensions()
+	if w <= 0 || h <= 0 {
+		return nil, ErrEmpty
+	}
+	if w > maxCells/h {
+		return nil, ErrTooLarge
+	}
+	l := &layout{
+		w:  w,
+		h:  h,
+		xs: make([]uint32, w*h),
+		ys: make([]uint32, w*h),
+		ts: make([]int, w*h),
+	}
+
+	ts := make([]uint64, w*h)
+	for i := range ts {
+		ts[i] = uint64(i)
+	}
+	if err := hilbert.MapBatch(curve, ts, l.xs, l.ys); err != nil {
+		return nil, err
+	}
+	for t := range ts {
+		l.ts[int(l.ys[t])*w+int(l.xs[t])] = t
+	}
+	return l, nil
+}
+
+// position returns the coordinates of the cell at index t.
+func (l *layout) position(t int) (x, y int) {
+	return int(l.xs[t]), int(l.ys[t])
+}
+
+// index returns the index of the cell at (x,y).
+func (l *layout) index(x, y int) int {
+	return l.ts[y*l.w+x]
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/hilbert"
+)
+
+func TestMeasure(t *testing.T) {
+	h, _ := hilbert.NewHilbert(16)
+	r, err := Measure(h, Options{Queries: 10, Seed: 1})
+	if err != nil {
+		t.Fatalf("Measure failed: %s", err)
+	}
+	if r.Name != "Hilbert" || r.Width != 16 || r.Height != 16 {
+		t.Errorf("Measure() = %s %dx%d want Hilbert 16x16", r.Name, r.Width, r.Height)
+	}
+
+	d, _ := ComputeDilation(h)
+	if r.Dilation != d {
+		t.Errorf("Measure().Dilation = %v want %v", r.Dilation, d)
+	}
+	bb, _ := ComputeBoundingBox(h)
+	if r.BoundingBox != bb {
+		t.Errorf("Measure().BoundingBox = %v want %v", r.BoundingBox, bb)
+	}
+	if r.Clustering.Max == 0 {
+		t.Errorf("Measure().Clustering = %v want some clusters", r.Clustering)
+	}
+	if len(r.Histogram) == 0 {
+		t.Errorf("Measure().Histogram is empty")
+	}
+}
+
+func TestMeasureTooLarge(t *testing.T) {
+	h, _ := hilbert.NewHilbert(512)
+	if _, err := Measure(h, Options{}); err != ErrTooLarge {
+		t.Errorf("Measure(512x512) err = %v want %v", err, ErrTooLarge)
+	}
+}
+
+func TestMeasureEmpty(t *testing.T) {
+	if _, err := Measure(&hilbert.Hilbert{}, Options{}); err != ErrEmpty {
+		t.Errorf("Measure(0x0) err = %v want %v", err, ErrEmpty)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	h, _ := hilbert.NewHilbert(8)
+	p, _ := hilbert.NewPeano(9)
+
+	var reports []*Report
+	for _, curve := range []hilbert.SpaceFilling{h, p} {
+		r, err := Measure(curve, Options{})
+		if err != nil {
+			t.Fatalf("Measure(%T) failed: %s", curve, err)
+		}
+		reports = append(reports, r)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, reports); err != nil {
+		t.Fatalf("WriteTable failed: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("WriteTable wrote %d lines want 3:\n%s", len(lines), buf.String())
+	}
+	for i, want := range []string{"curve", "Hilbert", "Peano"} {
+		if got := strings.Fields(lines[i])[0]; got != want {
+			t.Errorf("WriteTable line %d starts with %q want %q", i, got, want)
+		}
+	}
+}
+
+func TestHistogramSummary(t *testing.T) {
+	var testCases = []struct {
+		histogram []int
+		adjacent  float64
+		p90       int
+	}{
+		{nil, 0, 0},
+		{[]int{10}, 1, 1},
+		{[]int{5, 0, 5}, 0.5, 4},
+		{[]int{90, 5, 5}, 0.9, 1},
+		{[]int{80, 10, 0, 10}, 0.8, 2},
+	}
+
+	for _, tc := range testCases {
+		if got := adjacentFraction(tc.histogram); got != tc.adjacent {
+			t.Errorf("adjacentFraction(%v) = %v want %v", tc.histogram, got, tc.adjacent)
+		}
+		if got := histogramPercentile(tc.histogram, 0.9); got != tc.p90 {
+			t.Errorf("histogramPercentile(%v, 0.9) = %v want %v", tc.histogram, got, tc.p90)
+		}
+	}
+}
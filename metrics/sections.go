@@ -0,0 +1,125 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "github.com/google/hilbert"
+
+// Dilation holds the worst-case dilation of a curve, under three distance metrics. Each is the
+// largest value, over all sections of the curve, of the squared distance between the first and
+// last cells of the section divided by the number of cells in it. Smaller is better, and for
+// large Hilbert curves WLInf approaches 6, WL2 6 and WL1 9.
+type Dilation struct {
+	WLInf float64 // Maximum, or Chebyshev, distance.
+	WL2   float64 // Euclidean distance.
+	WL1   float64 // Manhattan distance.
+}
+
+// BoundingBox holds the ratio between the area of the bounding box of a section of the curve
+// and the number of cells in the section. A ratio of one means the section fills its box.
+type BoundingBox struct {
+	Worst   float64 // Largest ratio of any section.
+	Average float64 // Mean ratio of all sections of two or more cells.
+}
+
+// ComputeDilation returns the worst-case dilation of the curve. It takes time quadratic in the
+// number of cells.
+func ComputeDilation(curve hilbert.SpaceFilling) (Dilation, error) {
+	l, err := newLayout(curve)
+	if err != nil {
+		return Dilation{}, err
+	}
+	return l.dilation(), nil
+}
+
+// ComputeBoundingBox returns the bounding-box ratios of the sections of the curve. It takes
+// time quadratic in the number of cells.
+func ComputeBoundingBox(curve hilbert.SpaceFilling) (BoundingBox, error) {
+	l, err := newLayout(curve)
+	if err != nil {
+		return BoundingBox{}, err
+	}
+	return l.boundingBox(), nil
+}
+
+func (l *layout) dilation() Dilation {
+	var d Dilation
+	n := len(l.xs)
+	for a := 0; a < n; a++ {
+		ax, ay := l.position(a)
+		for b := a + 1; b < n; b++ {
+			bx, by := l.position(b)
+			dx, dy := abs(bx-ax), abs(by-ay)
+			area := float64(b - a + 1)
+
+			dinf := dx
+			if dy > dinf {
+				dinf = dy
+			}
+			if v := float64(dinf*dinf) / area; v > d.WLInf {
+				d.WLInf = v
+			}
+			if v := float64(dx*dx+dy*dy) / area; v > d.WL2 {
+				d.WL2 = v
+			}
+			if v := float64((dx+dy)*(dx+dy)) / area; v > d.WL1 {
+				d.WL1 = v
+			}
+		}
+	}
+	return d
+}
+
+func (l *layout) boundingBox() BoundingBox {
+	var bb BoundingBox
+	var sum float64
+	var count int
+
+	n := len(l.xs)
+	for a := 0; a < n; a++ {
+		minX, minY := l.position(a)
+		maxX, maxY := minX, minY
+		for b := a + 1; b < n; b++ {
+			x, y := l.position(b)
+			if x < minX {
+				minX = x
+			} else if x > maxX {
+				maxX = x
+			}
+			if y < minY {
+				minY = y
+			} else if y > maxY {
+				maxY = y
+			}
+
+			ratio := float64((maxX-minX+1)*(maxY-minY+1)) / float64(b-a+1)
+			if ratio > bb.Worst {
+				bb.Worst = ratio
+			}
+			sum += ratio
+			count++
+		}
+	}
+	if count > 0 {
+		bb.Average = sum / float64(count)
+	}
+	return bb
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/hilbert"
+)
+
+func TestDilationSmall(t *testing.T) {
+	// The 2x2 Hilbert curve visits (0,0), (0,1), (1,1) then (1,0). The worst sections are those
+	// of two cells for WLInf, and three cells for WL2 and WL1.
+	h, _ := hilbert.NewHilbert(2)
+	got, err := ComputeDilation(h)
+	if err != nil {
+		t.Fatalf("ComputeDilation failed: %s", err)
+	}
+	want := Dilation{WLInf: 1.0 / 2, WL2: 2.0 / 3, WL1: 4.0 / 3}
+	if !near(got.WLInf, want.WLInf) || !near(got.WL2, want.WL2) || !near(got.WL1, want.WL1) {
+		t.Errorf("ComputeDilation(2x2) = %v want %v", got, want)
+	}
+}
+
+func TestBoundingBoxSmall(t *testing.T) {
+	// Both three cell sections of the 2x2 Hilbert curve fill 3/4 of their box, and all other
+	// sections fill their box.
+	h, _ := hilbert.NewHilbert(2)
+	got, err := ComputeBoundingBox(h)
+	if err != nil {
+		t.Fatalf("ComputeBoundingBox failed: %s", err)
+	}
+	want := BoundingBox{Worst: 4.0 / 3, Average: (4 + 2*4.0/3) / 6}
+	if !near(got.Worst, want.Worst) || !near(got.Average, want.Average) {
+		t.Errorf("ComputeBoundingBox(2x2) = %v want %v", got, want)
+	}
+}
+
+func TestSectionsKnownBounds(t *testing.T) {
+	// The measures of finite curves approach the published values from below.
+	h, _ := hilbert.NewHilbert(32)
+	p, _ := hilbert.NewPeano(27)
+
+	var testCases = []struct {
+		curve     hilbert.SpaceFilling
+		dilation  Dilation
+		worstBBox float64
+	}{
+		{h, Dilation{WLInf: 6, WL2: 6, WL1: 9}, 2.4},
+		{p, Dilation{WLInf: 8, WL2: 8, WL1: 32.0 / 3}, 2},
+	}
+
+	for _, tc := range testCases {
+		d, err := ComputeDilation(tc.curve)
+		if err != nil {
+			t.Fatalf("ComputeDilation(%T) failed: %s", tc.curve, err)
+		}
+		if d.WLInf > tc.dilation.WLInf || d.WL2 > tc.dilation.WL2 || d.WL1 > tc.dilation.WL1 {
+			t.Errorf("ComputeDilation(%T) = %v want at most %v", tc.curve, d, tc.dilation)
+		}
+		if d.WLInf < tc.dilation.WLInf*3/4 || d.WL1 < tc.dilation.WL1*3/4 {
+			t.Errorf("ComputeDilation(%T) = %v want close to %v", tc.curve, d, tc.dilation)
+		}
+
+		bb, err := ComputeBoundingBox(tc.curve)
+		if err != nil {
+			t.Fatalf("ComputeBoundingBox(%T) failed: %s", tc.curve, err)
+		}
+		if bb.Worst > tc.worstBBox || bb.Worst < tc.worstBBox*3/4 {
+			t.Errorf("ComputeBoundingBox(%T).Worst = %v want close to %v", tc.curve, bb.Worst, tc.worstBBox)
+		}
+		if bb.Average < 1 || bb.Average > bb.Worst {
+			t.Errorf("ComputeBoundingBox(%T).Average = %v want within [1, %v]", tc.curve, bb.Average, bb.Worst)
+		}
+	}
+}
+
+func TestDilationMorton(t *testing.T) {
+	// The Morton curve jumps across the space, so its dilation grows with the space.
+	small, _ := hilbert.NewMorton(8)
+	large, _ := hilbert.NewMorton(32)
+
+	ds, _ := ComputeDilation(small)
+	dl, _ := ComputeDilation(large)
+	if dl.WLInf <= ds.WLInf {
+		t.Errorf("ComputeDilation(Morton 32).WLInf = %v want more than %v for Morton 8", dl.WLInf, ds.WLInf)
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func BenchmarkDilation(b *testing.B) {
+	h, _ := hilbert.NewHilbert(32)
+	for i := 0; i < b.N; i++ {
+		ComputeDilation(h)
+	}
+}
+
+func BenchmarkBoundingBox(b *testing.B) {
+	h, _ := hilbert.NewHilbert(32)
+	for i := 0; i < b.N; i++ {
+		ComputeBoundingBox(h)
+	}
+}
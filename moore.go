@@ -0,0 +1,121 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+// Moore represents a 2D Moore curve of order N for mapping to and from.
+// Implements SpaceFilling interface.
+//
+// The Moore curve is the closed loop variant of the Hilbert curve. It is made of four Hilbert
+// curves, one per quadrant, visited in the order lower left, upper left, upper right and lower
+// right, so the curve starts at (N/2-1, 0) and ends next to it at (N/2, 0).
+type Moore struct {
+	N int
+
+	sub Hilbert // Curve for each quadrant, of order N/2
+}
+
+// NewMoore returns a Moore space which maps integers to and from the curve.
+// n must be a power of two, and n^2 must fit in an int.
+func NewMoore(n int) (*Moore, error) {
+	if n <= 0 {
+		return nil, ErrNotPositive
+	}
+
+	// Test if power of two
+	if (n & (n - 1)) != 0 {
+		return nil, ErrNotPowerOfTwo
+	}
+
+	// Test if n^2, the size of the space, fits in an int
+	if n > maxInt/n {
+		return nil, ErrOverflow
+	}
+
+	return &Moore{
+		N:   n,
+		sub: Hilbert{N: n / 2},
+	}, nil
+}
+
+// GetDimensions returns the width and height of the 2D space.
+func (s *Moore) GetDimensions() (int, int) {
+	return s.N, s.N
+}
+
+// Map transforms a one dimension value, t, in the range [0, n^2-1] to coordinates on the Moore
+// curve in the two-dimension space, where x and y are within [0,n-1].
+func (s *Moore) Map(t int) (x, y int, err error) {
+	if t < 0 || t >= s.N*s.N {
+		return -1, -1, ErrOutOfRange
+	}
+
+	h := s.sub.N
+	if h == 0 {
+		return 0, 0, nil
+	}
+
+	q := t / (h * h)
+	x, y, err = s.sub.Map(t % (h * h))
+	if err != nil {
+		return -1, -1, err
+	}
+
+	// Mirroring the Hilbert curve reverses it, then flipping it about a diagonal turns it to
+	// run up the left quadrants, or down the right quadrants.
+	x = h - 1 - x
+	x, y = s.sub.rotate(h, x, y, q < 2, false)
+
+	x += h * b2i(q >= 2)
+	y += h * b2i(q == 1 || q == 2)
+	return x, y, nil
+}
+
+// MapInverse transform coordinates on Moore curve from (x,y) to t.
+func (s *Moore) MapInverse(x, y int) (t int, err error) {
+	if x < 0 || x >= s.N || y < 0 || y >= s.N {
+		return -1, ErrOutOfRange
+	}
+
+	h := s.sub.N
+	if h == 0 {
+		return 0, nil
+	}
+
+	rx := x >= h
+	ry := y >= h
+
+	q := 0
+	switch {
+	case !rx && ry:
+		q = 1
+	case rx && ry:
+		q = 2
+	case rx && !ry:
+		q = 3
+	}
+
+	x -= h * b2i(rx)
+	y -= h * b2i(ry)
+
+	// Undo the flips applied by Map, each of which is its own inverse.
+	x, y = s.sub.rotate(h, x, y, !rx, false)
+	x = h - 1 - x
+
+	t, err = s.sub.MapInverse(x, y)
+	if err != nil {
+		return -1, err
+	}
+	return q*h*h + t, nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"testing"
+)
+
+// Test cases below assume N=4
+var mooreTestCases = []struct {
+	d, x, y int
+}{
+	{0, 1, 0},
+	{1, 0, 0},
+	{2, 0, 1},
+	{3, 1, 1},
+	{4, 1, 2},
+	{7, 1, 3},
+	{8, 2, 3},
+	{11, 2, 2},
+	{12, 2, 1},
+	{15, 2, 0},
+}
+
+func TestMooreNewErrors(t *testing.T) {
+	var newTestCases = []struct {
+		n       int
+		wantErr error
+	}{
+		{-1, ErrNotPositive},
+		{0, ErrNotPositive},
+		{3, ErrNotPowerOfTwo},
+		{5, ErrNotPowerOfTwo},
+		{1 << (uintSize / 2), ErrOverflow},
+	}
+
+	for _, tc := range newTestCases {
+		s, err := NewMoore(tc.n)
+		if s != nil || err != tc.wantErr {
+			t.Errorf("NewMoore(%d) = (%+v, %q) did not fail want (nil, %q)", tc.n, s, err, tc.wantErr)
+		}
+	}
+}
+
+func TestMooreRangeErrors(t *testing.T) {
+	s, err := NewMoore(16)
+	if err != nil {
+		t.Fatalf("NewMoore(16) failed: %s", err)
+	}
+
+	var mapRangeTestCases = []struct {
+		d       int
+		wantErr error
+	}{
+		{-1, ErrOutOfRange},
+		{0, nil},
+		{255, nil},
+		{256, ErrOutOfRange},
+	}
+	for _, tc := range mapRangeTestCases {
+		if _, _, err = s.Map(tc.d); err != tc.wantErr {
+			t.Errorf("Map(%d) = %q want %q", tc.d, err, tc.wantErr)
+		}
+	}
+
+	var mapInverseRangeTestCases = []struct {
+		x, y    int
+		wantErr error
+	}{
+		{0, 0, nil},
+		{15, 15, nil},
+		{-1, 0, ErrOutOfRange},
+		{0, -1, ErrOutOfRange},
+		{16, 0, ErrOutOfRange},
+		{0, 16, ErrOutOfRange},
+	}
+	for _, tc := range mapInverseRangeTestCases {
+		if _, err = s.MapInverse(tc.x, tc.y); err != tc.wantErr {
+			t.Errorf("MapInverse(%d, %d) = %q want %q", tc.x, tc.y, err, tc.wantErr)
+		}
+	}
+}
+
+func TestMooreMap(t *testing.T) {
+	s, err := NewMoore(4)
+	if err != nil {
+		t.Fatalf("NewMoore(4) failed: %s", err)
+	}
+
+	for _, tc := range mooreTestCases {
+		x, y, err := s.Map(tc.d)
+		if err != nil {
+			t.Errorf("Map(%d) returned error: %s", tc.d, err)
+		}
+		if x != tc.x || y != tc.y {
+			t.Errorf("Map(%d) = (%d, %d) want (%d, %d)", tc.d, x, y, tc.x, tc.y)
+		}
+
+		d, err := s.MapInverse(tc.x, tc.y)
+		if err != nil {
+			t.Errorf("MapInverse(%d, %d) returned error: %s", tc.x, tc.y, err)
+		}
+		if d != tc.d {
+			t.Errorf("MapInverse(%d, %d) = %d want %d", tc.x, tc.y, d, tc.d)
+		}
+	}
+}
+
+func TestMooreAllMapValues(t *testing.T) {
+	for _, n := range []int{1, 2, 4, 8, 16, 32, 64} {
+		s, err := NewMoore(n)
+		if err != nil {
+			t.Fatalf("NewMoore(%d) failed: %s", n, err)
+		}
+
+		seen := make(map[[2]int]bool)
+		for d := 0; d < s.N*s.N; d++ {
+			// Map forwards and then back
+			x, y, err := s.Map(d)
+			if err != nil {
+				t.Errorf("N=%d: Map(%d) returned error: %s", n, d, err)
+			}
+			if x < 0 || x >= s.N || y < 0 || y >= s.N {
+				t.Errorf("N=%d: Map(%d) returned x,y out of range: (%d, %d)", n, d, x, y)
+			}
+			if seen[[2]int{x, y}] {
+				t.Errorf("N=%d: Map(%d) = (%d, %d) visited twice", n, d, x, y)
+			}
+			seen[[2]int{x, y}] = true
+
+			dPrime, err := s.MapInverse(x, y)
+			if err != nil {
+				t.Errorf("N=%d: MapInverse(%d, %d) returned error: %s", n, x, y, err)
+			}
+			if d != dPrime {
+				t.Errorf("N=%d: Failed Map(%d) -> MapInverse(%d, %d) -> %d", n, d, x, y, dPrime)
+			}
+
+			// Each point must be next to the following one, and the last wraps to the first.
+			next := (d + 1) % (s.N * s.N)
+			nx, ny, err := s.Map(next)
+			if err != nil {
+				t.Errorf("N=%d: Map(%d) returned error: %s", n, next, err)
+			}
+			if n > 1 && abs(x-nx)+abs(y-ny) != 1 {
+				t.Errorf("N=%d: Map(%d) = (%d, %d) is not next to Map(%d) = (%d, %d)", n, d, x, y, next, nx, ny)
+			}
+		}
+	}
+}
+
+func BenchmarkMooreMap(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s, err := NewMoore(benchmarkN)
+		if err != nil {
+			b.Fatalf("NewMoore(%d) failed: %s", benchmarkN, err)
+		}
+		for d := 0; d < benchmarkN*benchmarkN; d++ {
+			s.Map(d)
+		}
+	}
+}
+
+func BenchmarkMooreMapInverse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s, err := NewMoore(benchmarkN)
+		if err != nil {
+			b.Fatalf("NewMoore(%d) failed: %s", benchmarkN, err)
+		}
+
+		for x := 0; x < benchmarkN; x++ {
+			for y := 0; y < benchmarkN; y++ {
+				s.MapInverse(x, y)
+			}
+		}
+	}
+}
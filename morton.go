@@ -0,0 +1,209 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+// Morton represents a 2D Morton (Z-order) space of order N for mapping to and from.
+// Implements SpaceFilling interface.
+//
+// Unlike the Hilbert curve, the Morton curve is not continuous, but it is cheap to compute as
+// t is just the bits of x and y interleaved, with x in the odd bits and y in the even bits.
+type Morton struct {
+	N int
+}
+
+// NewMorton returns a Morton space which maps integers to and from the curve.
+// n must be a power of two, and n^2 must fit in an int.
+func NewMorton(n int) (*Morton, error) {
+	if n <= 0 {
+		return nil, ErrNotPositive
+	}
+
+	// Test if power of two
+	if (n & (n - 1)) != 0 {
+		return nil, ErrNotPowerOfTwo
+	}
+
+	// Test if n^2, the size of the space, fits in an int
+	if n > maxInt/n {
+		return nil, ErrOverflow
+	}
+
+	return &Morton{
+		N: n,
+	}, nil
+}
+
+// GetDimensions returns the width and height of the 2D space.
+func (s *Morton) GetDimensions() (int, int) {
+	return s.N, s.N
+}
+
+// Map transforms a one dimension value, t, in the range [0, n^2-1] to coordinates on the Morton
+// curve in the two-dimension space, where x and y are within [0,n-1].
+func (s *Morton) Map(t int) (x, y int, err error) {
+	if t < 0 || t >= s.N*s.N {
+		return -1, -1, ErrOutOfRange
+	}
+
+	x32, y32 := mortonDecode(uint64(t))
+	return int(x32), int(y32), nil
+}
+
+// MapInverse transform coordinates on Morton curve from (x,y) to t.
+func (s *Morton) MapInverse(x, y int) (t int, err error) {
+	if x < 0 || x >= s.N || y < 0 || y >= s.N {
+		return -1, ErrOutOfRange
+	}
+
+	return int(mortonEncode(uint32(x), uint32(y))), nil
+}
+
+// MapBatch transforms each value in ts to coordinates on the Morton curve, storing them in the
+// same position in xs and ys. The slices must be the same length. If any value is out of range
+// ErrOutOfRange is returned, and the coordinates of the values before it are still stored.
+func (s *Morton) MapBatch(ts []uint64, xs, ys []uint32) error {
+	if len(xs) != len(ts) || len(ys) != len(ts) {
+		return ErrLengthMismatch
+	}
+	xs, ys = xs[:len(ts)], ys[:len(ts)]
+
+	size := uint64(s.N) * uint64(s.N)
+	for j, t := range ts {
+		if t >= size {
+			return ErrOutOfRange
+		}
+		xs[j], ys[j] = mortonDecode(t)
+	}
+	return nil
+}
+
+// MapInverseBatch transforms each pair of coordinates in xs and ys to a value on the Morton
+// curve, storing them in the same position in ts. The slices must be the same length. If any
+// pair is out of range ErrOutOfRange is returned, and the values of the pairs before it are
+// still stored.
+func (s *Morton) MapInverseBatch(xs, ys []uint32, ts []uint64) error {
+	if len(ys) != len(xs) || len(ts) != len(xs) {
+		return ErrLengthMismatch
+	}
+	ys, ts = ys[:len(xs)], ts[:len(xs)]
+
+	n := uint32(s.N)
+	for j, x := range xs {
+		y := ys[j]
+		if x >= n || y >= n {
+			return ErrOutOfRange
+		}
+		ts[j] = mortonEncode(x, y)
+	}
+	return nil
+}
+
+// mortonEncode interleaves the bits of x and y, with x in the odd bits and y in the even bits.
+// On amd64 the BMI2 PDEP instruction is used if it is available.
+func mortonEncode(x, y uint32) uint64 {
+	if useBMI2 {
+		return mortonEncodeBMI2(x, y)
+	}
+	return mortonEncodeGeneric(x, y)
+}
+
+// mortonDecode is the inverse of mortonEncode. On amd64 the BMI2 PEXT instruction is used if
+// it is available.
+func mortonDecode(t uint64) (x, y uint32) {
+	if useBMI2 {
+		return mortonDecodeBMI2(t)
+	}
+	return mortonDecodeGeneric(t)
+}
+
+// mortonEncodeGeneric is the pure Go implementation of mortonEncode.
+func mortonEncodeGeneric(x, y uint32) uint64 {
+	return spread(uint64(x))<<1 | spread(uint64(y))
+}
+
+// mortonDecodeGeneric is the pure Go implementation of mortonDecode.
+func mortonDecodeGeneric(t uint64) (x, y uint32) {
+	return uint32(compact(t >> 1)), uint32(compact(t))
+}
+
+// spread spaces out the lower 32 bits of v, so bit i moves to bit 2i.
+func spread(v uint64) uint64 {
+	v &= 0x00000000ffffffff
+	v = (v | v<<16) & 0x0000ffff0000ffff
+	v = (v | v<<8) & 0x00ff00ff00ff00ff
+	v = (v | v<<4) & 0x0f0f0f0f0f0f0f0f
+	v = (v | v<<2) & 0x3333333333333333
+	v = (v | v<<1) & 0x5555555555555555
+	return v
+}
+
+// compact is the inverse of spread, gathering the even bits of v into the lower 32 bits.
+func compact(v uint64) uint64 {
+	v &= 0x5555555555555555
+	v = (v | v>>1) & 0x3333333333333333
+	v = (v | v>>2) & 0x0f0f0f0f0f0f0f0f
+	v = (v | v>>4) & 0x00ff00ff00ff00ff
+	v = (v | v>>8) & 0x0000ffff0000ffff
+	v = (v | v>>16) & 0x00000000ffffffff
+	return v
+}
+
+// Morton64 represents a 2D Morton (Z-order) space of order N, for mapping to and from, with 64
+// bit indexes. It produces the same curve as Morton, but N may be as large as 2^32.
+// Implements SpaceFilling64 interface.
+type Morton64 struct {
+	N uint64
+
+	max uint64 // Largest valid index, N^2 - 1.
+}
+
+// NewMorton64 returns a Morton space which maps integers to and from the curve.
+// n must be a power of two, no larger than 2^32.
+func NewMorton64(n uint64) (*Morton64, error) {
+	max, err := newMax64(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Morton64{
+		N:   n,
+		max: max,
+	}, nil
+}
+
+// GetDimensions returns the width and height of the 2D space.
+func (s *Morton64) GetDimensions() (uint64, uint64) {
+	return s.N, s.N
+}
+
+// Map transforms a one dimension value, t, in the range [0, n^2-1] to coordinates on the Morton
+// curve in the two-dimension space, where x and y are within [0,n-1].
+func (s *Morton64) Map(t uint64) (x, y uint32, err error) {
+	if t > s.max {
+		return 0, 0, ErrOutOfRange
+	}
+
+	x, y = mortonDecode(t)
+	return x, y, nil
+}
+
+// MapInverse transform coordinates on Morton curve from (x,y) to t.
+func (s *Morton64) MapInverse(x, y uint32) (t uint64, err error) {
+	if uint64(x) >= s.N || uint64(y) >= s.N {
+		return 0, ErrOutOfRange
+	}
+
+	return mortonEncode(x, y), nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package hilbert
+
+// useBMI2 is true if the CPU supports the BMI2 PDEP and PEXT instructions, which interleave and
+// deinterleave bits in a single instruction.
+var useBMI2 = hasBMI2()
+
+// hasBMI2 returns true if the CPU supports BMI2.
+func hasBMI2() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 7 {
+		return false
+	}
+	_, ebx, _, _ := cpuid(7, 0)
+	return ebx&(1<<8) != 0
+}
+
+// cpuid executes the CPUID instruction with the given EAX and ECX arguments.
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// mortonEncodeBMI2 is the same as mortonEncodeGeneric, implemented with PDEP.
+func mortonEncodeBMI2(x, y uint32) uint64
+
+// mortonDecodeBMI2 is the same as mortonDecodeGeneric, implemented with PEXT.
+func mortonDecodeBMI2(t uint64) (x, y uint32)
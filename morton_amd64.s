@@ -0,0 +1,52 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func mortonEncodeBMI2(x, y uint32) uint64
+TEXT ·mortonEncodeBMI2(SB), NOSPLIT, $0-16
+	MOVLQZX x+0(FP), AX
+	MOVLQZX y+4(FP), BX
+	MOVQ    $0xaaaaaaaaaaaaaaaa, CX
+	PDEPQ   CX, AX, AX           // x into the odd bits
+	MOVQ    $0x5555555555555555, CX
+	PDEPQ   CX, BX, BX           // y into the even bits
+	ORQ     BX, AX
+	MOVQ    AX, ret+8(FP)
+	RET
+
+// func mortonDecodeBMI2(t uint64) (x, y uint32)
+TEXT ·mortonDecodeBMI2(SB), NOSPLIT, $0-16
+	MOVQ  t+0(FP), AX
+	MOVQ  $0xaaaaaaaaaaaaaaaa, CX
+	PEXTQ CX, AX, BX             // x from the odd bits
+	MOVQ  $0x5555555555555555, CX
+	PEXTQ CX, AX, AX             // y from the even bits
+	MOVL  BX, x+8(FP)
+	MOVL  AX, y+12(FP)
+	RET
@@ -0,0 +1,58 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package hilbert
+
+import (
+	"testing"
+)
+
+func FuzzMorton(f *testing.F) {
+	f.Add(uint8(3), 0)
+	f.Add(uint8(5), 1023)
+	f.Add(uint8(31), maxInt/4)
+
+	f.Fuzz(func(t *testing.T, order uint8, d int) {
+		order %= uintSize/2 - 1
+		s, err := NewMorton(1 << order)
+		if err != nil {
+			t.Fatalf("NewMorton(%d) failed: %s", 1<<order, err)
+		}
+
+		x, y, err := s.Map(d)
+		if d < 0 || d >= s.N*s.N {
+			if err != ErrOutOfRange {
+				t.Fatalf("N=%d: Map(%d) = %q want %q", s.N, d, err, ErrOutOfRange)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("N=%d: Map(%d) returned error: %s", s.N, d, err)
+		}
+		if x < 0 || x >= s.N || y < 0 || y >= s.N {
+			t.Fatalf("N=%d: Map(%d) returned x,y out of range: (%d, %d)", s.N, d, x, y)
+		}
+
+		dPrime, err := s.MapInverse(x, y)
+		if err != nil {
+			t.Fatalf("N=%d: MapInverse(%d, %d) returned error: %s", s.N, x, y, err)
+		}
+		if d != dPrime {
+			t.Fatalf("N=%d: Failed Map(%d) -> MapInverse(%d, %d) -> %d", s.N, d, x, y, dPrime)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !amd64 || purego
+// +build !amd64 purego
+
+package hilbert
+
+// useBMI2 is always false on other platforms, so the pure Go code is used.
+const useBMI2 = false
+
+func mortonEncodeBMI2(x, y uint32) uint64 {
+	panic("assertion failure: BMI2 is not supported on this platform")
+}
+
+func mortonDecodeBMI2(t uint64) (x, y uint32) {
+	panic("assertion failure: BMI2 is not supported on this platform")
+}
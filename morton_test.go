@@ -0,0 +1,259 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Test cases below assume N=8
+var mortonTestCases = []struct {
+	d, x, y int
+}{
+	{0, 0, 0},
+	{1, 0, 1},
+	{2, 1, 0},
+	{3, 1, 1},
+	{4, 0, 2},
+	{11, 3, 1},
+	{12, 2, 2},
+	{42, 7, 0},
+	{63, 7, 7},
+}
+
+func TestMortonNewErrors(t *testing.T) {
+	var newTestCases = []struct {
+		n       int
+		wantErr error
+	}{
+		{-1, ErrNotPositive},
+		{0, ErrNotPositive},
+		{3, ErrNotPowerOfTwo},
+		{5, ErrNotPowerOfTwo},
+		{1 << (uintSize / 2), ErrOverflow},
+	}
+
+	for _, tc := range newTestCases {
+		s, err := NewMorton(tc.n)
+		if s != nil || err != tc.wantErr {
+			t.Errorf("NewMorton(%d) = (%+v, %q) did not fail want (nil, %q)", tc.n, s, err, tc.wantErr)
+		}
+	}
+}
+
+func TestMortonRangeErrors(t *testing.T) {
+	s, err := NewMorton(8)
+	if err != nil {
+		t.Fatalf("NewMorton(8) failed: %s", err)
+	}
+
+	var mapRangeTestCases = []struct {
+		d       int
+		wantErr error
+	}{
+		{-1, ErrOutOfRange},
+		{0, nil},
+		{63, nil},
+		{64, ErrOutOfRange},
+	}
+	for _, tc := range mapRangeTestCases {
+		if _, _, err = s.Map(tc.d); err != tc.wantErr {
+			t.Errorf("Map(%d) = %q want %q", tc.d, err, tc.wantErr)
+		}
+	}
+
+	var mapInverseRangeTestCases = []struct {
+		x, y    int
+		wantErr error
+	}{
+		{0, 0, nil},
+		{7, 7, nil},
+		{-1, 0, ErrOutOfRange},
+		{0, -1, ErrOutOfRange},
+		{8, 0, ErrOutOfRange},
+		{0, 8, ErrOutOfRange},
+	}
+	for _, tc := range mapInverseRangeTestCases {
+		if _, err = s.MapInverse(tc.x, tc.y); err != tc.wantErr {
+			t.Errorf("MapInverse(%d, %d) = %q want %q", tc.x, tc.y, err, tc.wantErr)
+		}
+	}
+}
+
+func TestMortonMap(t *testing.T) {
+	s, err := NewMorton(8)
+	if err != nil {
+		t.Fatalf("NewMorton(8) failed: %s", err)
+	}
+
+	for _, tc := range mortonTestCases {
+		x, y, err := s.Map(tc.d)
+		if err != nil {
+			t.Errorf("Map(%d) returned error: %s", tc.d, err)
+		}
+		if x != tc.x || y != tc.y {
+			t.Errorf("Map(%d) = (%d, %d) want (%d, %d)", tc.d, x, y, tc.x, tc.y)
+		}
+
+		d, err := s.MapInverse(tc.x, tc.y)
+		if err != nil {
+			t.Errorf("MapInverse(%d, %d) returned error: %s", tc.x, tc.y, err)
+		}
+		if d != tc.d {
+			t.Errorf("MapInverse(%d, %d) = %d want %d", tc.x, tc.y, d, tc.d)
+		}
+	}
+}
+
+func TestMortonAllMapValues(t *testing.T) {
+	s, err := NewMorton(32)
+	if err != nil {
+		t.Fatalf("NewMorton(32) failed: %s", err)
+	}
+
+	for d := 0; d < s.N*s.N; d++ {
+		// Map forwards and then back
+		x, y, err := s.Map(d)
+		if err != nil {
+			t.Errorf("Map(%d) returned error: %s", d, err)
+		}
+		if x < 0 || x >= s.N || y < 0 || y >= s.N {
+			t.Errorf("Map(%d) returned x,y out of range: (%d, %d)", d, x, y)
+		}
+
+		dPrime, err := s.MapInverse(x, y)
+		if err != nil {
+			t.Errorf("MapInverse(%d, %d) returned error: %s", x, y, err)
+		}
+		if d != dPrime {
+			t.Errorf("Failed Map(%d) -> MapInverse(%d, %d) -> %d", d, x, y, dPrime)
+		}
+	}
+}
+
+func TestSpreadCompact(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		v := uint64(r.Uint32())
+		s := spread(v)
+		if s&0xaaaaaaaaaaaaaaaa != 0 {
+			t.Errorf("spread(%#x) = %#x has odd bits set", v, s)
+		}
+		if got := compact(s); got != v {
+			t.Errorf("compact(spread(%#x)) = %#x want %#x", v, got, v)
+		}
+	}
+}
+
+func BenchmarkMortonMap(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s, err := NewMorton(benchmarkN)
+		if err != nil {
+			b.Fatalf("NewMorton(%d) failed: %s", benchmarkN, err)
+		}
+		for d := 0; d < benchmarkN*benchmarkN; d++ {
+			s.Map(d)
+		}
+	}
+}
+
+func BenchmarkMortonMapRandom(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s, err := NewMorton(benchmarkN)
+		if err != nil {
+			b.Fatalf("NewMorton(%d) failed: %s", benchmarkN, err)
+		}
+		for d := 0; d < benchmarkN*benchmarkN; d++ {
+			rd := rand.Intn(benchmarkN * benchmarkN) // Pick a random d
+			s.Map(rd)
+		}
+	}
+}
+
+func BenchmarkMortonMapInverse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s, err := NewMorton(benchmarkN)
+		if err != nil {
+			b.Fatalf("NewMorton(%d) failed: %s", benchmarkN, err)
+		}
+
+		for x := 0; x < benchmarkN; x++ {
+			for y := 0; y < benchmarkN; y++ {
+				s.MapInverse(x, y)
+			}
+		}
+	}
+}
+
+// TestMortonBMI2 checks the BMI2 implementation, if it is available, agrees with the pure Go
+// implementation.
+func TestMortonBMI2(t *testing.T) {
+	if !useBMI2 {
+		t.Skip("BMI2 is not available")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		x, y := r.Uint32(), r.Uint32()
+		switch i {
+		case 0:
+			x, y = 0, 0
+		case 1:
+			x, y = ^uint32(0), ^uint32(0)
+		}
+
+		want := mortonEncodeGeneric(x, y)
+		if got := mortonEncodeBMI2(x, y); got != want {
+			t.Errorf("mortonEncodeBMI2(%#x, %#x) = %#x want %#x", x, y, got, want)
+		}
+
+		d := r.Uint64()
+		wantX, wantY := mortonDecodeGeneric(d)
+		if gotX, gotY := mortonDecodeBMI2(d); gotX != wantX || gotY != wantY {
+			t.Errorf("mortonDecodeBMI2(%#x) = (%#x, %#x) want (%#x, %#x)", d, gotX, gotY, wantX, wantY)
+		}
+	}
+}
+
+func BenchmarkMortonEncodeGeneric(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		mortonEncodeGeneric(uint32(i), uint32(i)*7)
+	}
+}
+
+func BenchmarkMortonEncodeBMI2(b *testing.B) {
+	if !useBMI2 {
+		b.Skip("BMI2 is not available")
+	}
+	for i := 0; i < b.N; i++ {
+		mortonEncodeBMI2(uint32(i), uint32(i)*7)
+	}
+}
+
+func BenchmarkMortonDecodeGeneric(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		mortonDecodeGeneric(uint64(i) * 0x9e3779b97f4a7c15)
+	}
+}
+
+func BenchmarkMortonDecodeBMI2(b *testing.B) {
+	if !useBMI2 {
+		b.Skip("BMI2 is not available")
+	}
+	for i := 0; i < b.N; i++ {
+		mortonDecodeBMI2(uint64(i) * 0x9e3779b97f4a7c15)
+	}
+}
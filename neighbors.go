@@ -0,0 +1,71 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+// Offsets to the neighbours of a point. The first four share an edge with it, in the same order
+// as the Directions, and the rest share only a corner.
+var neighborOffsets = [8][2]int{
+	{1, 0}, {0, 1}, {-1, 0}, {0, -1},
+	{1, 1}, {-1, 1}, {-1, -1}, {1, -1},
+}
+
+// Neighbors returns the indexes of the points that share an edge with the point at index t,
+// in the order right, up, left and down. Points beyond the edge of the space are skipped.
+func (s *Hilbert) Neighbors(t int) ([]int, error) {
+	return neighbors(s, t, 4)
+}
+
+// Neighbors8 returns the indexes of the points that share an edge or a corner with the point
+// at index t. The four that share an edge come first, in the same order as Neighbors. Points
+// beyond the edge of the space are skipped.
+func (s *Hilbert) Neighbors8(t int) ([]int, error) {
+	return neighbors(s, t, 8)
+}
+
+// Neighbors returns the indexes of the points that share an edge with the point at index t,
+// in the order right, up, left and down. Points beyond the edge of the space are skipped.
+func (p *Peano) Neighbors(t int) ([]int, error) {
+	return neighbors(p, t, 4)
+}
+
+// Neighbors8 returns the indexes of the points that share an edge or a corner with the point
+// at index t. The four that share an edge come first, in the same order as Neighbors. Points
+// beyond the edge of the space are skipped.
+func (p *Peano) Neighbors8(t int) ([]int, error) {
+	return neighbors(p, t, 8)
+}
+
+// neighbors returns the indexes of the first count neighbours of the point at index t.
+func neighbors(curve SpaceFilling, t, count int) ([]int, error) {
+	x, y, err := curve.Map(t)
+	if err != nil {
+		return nil, err
+	}
+	w, h := curve.GetDimensions()
+
+	result := make([]int, 0, count)
+	for _, o := range neighborOffsets[:count] {
+		nx, ny := x+o[0], y+o[1]
+		if nx < 0 || nx >= w || ny < 0 || ny >= h {
+			continue
+		}
+		nt, err := curve.MapInverse(nx, ny)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, nt)
+	}
+	return result, nil
+}
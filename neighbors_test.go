@@ -0,0 +1,175 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"reflect"
+	"testing"
+)
+
+// neighborer is implemented by the curves with Neighbors methods.
+type neighborer interface {
+	SpaceFilling
+	Neighbors(t int) ([]int, error)
+	Neighbors8(t int) ([]int, error)
+}
+
+func TestNeighbors(t *testing.T) {
+	h, _ := NewHilbert(4)
+
+	var testCases = []struct {
+		x, y  int
+		want4 []Point
+		want8 []Point
+	}{
+		{0, 0, // corner
+			[]Point{{1, 0}, {0, 1}},
+			[]Point{{1, 0}, {0, 1}, {1, 1}}},
+		{3, 3, // corner
+			[]Point{{2, 3}, {3, 2}},
+			[]Point{{2, 3}, {3, 2}, {2, 2}}},
+		{0, 2, // edge
+			[]Point{{1, 2}, {0, 3}, {0, 1}},
+			[]Point{{1, 2}, {0, 3}, {0, 1}, {1, 3}, {1, 1}}},
+		{1, 2, // middle
+			[]Point{{2, 2}, {1, 3}, {0, 2}, {1, 1}},
+			[]Point{{2, 2}, {1, 3}, {0, 2}, {1, 1}, {2, 3}, {0, 3}, {0, 1}, {2, 1}}},
+	}
+
+	for _, tc := range testCases {
+		ti, _ := h.MapInverse(tc.x, tc.y)
+		for _, q := range []struct {
+			name string
+			f    func(int) ([]int, error)
+			want []Point
+		}{
+			{"Neighbors", h.Neighbors, tc.want4},
+			{"Neighbors8", h.Neighbors8, tc.want8},
+		} {
+			got, err := q.f(ti)
+			if err != nil {
+				t.Errorf("%s(%d) failed: %s", q.name, ti, err)
+				continue
+			}
+			if points := mapPoints(t, h, got); !reflect.DeepEqual(points, q.want) {
+				t.Errorf("%s(%d) at (%d,%d) = points %v want %v", q.name, ti, tc.x, tc.y, points, q.want)
+			}
+		}
+	}
+}
+
+// mapPoints returns the points of the indexes ts, in the same order.
+func mapPoints(t *testing.T, curve SpaceFilling, ts []int) []Point {
+	points := make([]Point, len(ts))
+	for i, ti := range ts {
+		x, y, err := curve.Map(ti)
+		if err != nil {
+			t.Fatalf("Map(%d) failed: %s", ti, err)
+		}
+		points[i] = Point{x, y}
+	}
+	return points
+}
+
+func TestNeighborsAll(t *testing.T) {
+	var curves []neighborer
+	for _, n := range []int{1, 2, 16} {
+		h, _ := NewHilbert(n)
+		curves = append(curves, h)
+	}
+	table, _ := NewHilbertTable(32, 8)
+	curves = append(curves, table)
+	for _, n := range []int{1, 3, 27} {
+		p, _ := NewPeano(n)
+		curves = append(curves, p)
+	}
+
+	for _, curve := range curves {
+		n, _ := curve.GetDimensions()
+		for ti := 0; ti < n*n; ti++ {
+			x, y, _ := curve.Map(ti)
+
+			// Count the points around (x,y) within the space.
+			want4, want8 := 0, 0
+			for dx := -1; dx <= 1; dx++ {
+				for dy := -1; dy <= 1; dy++ {
+					if (dx == 0 && dy == 0) || x+dx < 0 || x+dx >= n || y+dy < 0 || y+dy >= n {
+						continue
+					}
+					want8++
+					if dx == 0 || dy == 0 {
+						want4++
+					}
+				}
+			}
+
+			got4, err := curve.Neighbors(ti)
+			if err != nil {
+				t.Fatalf("%T %d: Neighbors(%d) failed: %s", curve, n, ti, err)
+			}
+			got8, err := curve.Neighbors8(ti)
+			if err != nil {
+				t.Fatalf("%T %d: Neighbors8(%d) failed: %s", curve, n, ti, err)
+			}
+			if len(got4) != want4 || len(got8) != want8 {
+				t.Fatalf("%T %d: got %d and %d neighbours of (%d,%d) want %d and %d", curve, n, len(got4), len(got8), x, y, want4, want8)
+			}
+			if !reflect.DeepEqual(got4, got8[:len(got4)]) {
+				t.Errorf("%T %d: Neighbors(%d) = %v is not the start of Neighbors8 = %v", curve, n, ti, got4, got8)
+			}
+
+			for i, nt := range got8 {
+				nx, ny, _ := curve.Map(nt)
+				dx, dy := abs(nx-x), abs(ny-y)
+				if dx > 1 || dy > 1 || dx+dy == 0 || (i < len(got4)) != (dx+dy == 1) {
+					t.Errorf("%T %d: Neighbors8(%d) gave (%d,%d) for (%d,%d)", curve, n, ti, nx, ny, x, y)
+				}
+			}
+
+			// Both curves are continuous, so the previous and next points are neighbours.
+			for _, adj := range []int{ti - 1, ti + 1} {
+				if adj >= 0 && adj < n*n && !containsInt(got4, adj) {
+					t.Errorf("%T %d: Neighbors(%d) = %v is missing %d", curve, n, ti, got4, adj)
+				}
+			}
+		}
+	}
+}
+
+func containsInt(s []int, v int) bool {
+	for _, i := range s {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNeighborsOutOfRange(t *testing.T) {
+	h, _ := NewHilbert(4)
+	p, _ := NewPeano(3)
+
+	for _, curve := range []neighborer{h, p} {
+		n, _ := curve.GetDimensions()
+		for _, ti := range []int{-1, n * n} {
+			if _, err := curve.Neighbors(ti); err != ErrOutOfRange {
+				t.Errorf("%T: Neighbors(%d) err = %v want %v", curve, ti, err, ErrOutOfRange)
+			}
+			if _, err := curve.Neighbors8(ti); err != ErrOutOfRange {
+				t.Errorf("%T: Neighbors8(%d) err = %v want %v", curve, ti, err, ErrOutOfRange)
+			}
+		}
+	}
+}
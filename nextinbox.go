@@ -0,0 +1,123 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+// NextInBox returns the smallest index, no less than t, whose cell is within the box. ok is
+// false if there is no such index. This is the BIGMIN operation, which allows a scan over
+// sorted keys to jump straight back into the box once it has stepped outside.
+func (s *Hilbert) NextInBox(t int, box Box) (next int, ok bool) {
+	return nextInBox(s, t, box)
+}
+
+// PrevInBox returns the largest index, no greater than t, whose cell is within the box. ok is
+// false if there is no such index. This is the LITMAX operation, the reverse of NextInBox.
+func (s *Hilbert) PrevInBox(t int, box Box) (prev int, ok bool) {
+	return prevInBox(s, t, box)
+}
+
+// NextInBox returns the smallest index, no less than t, whose cell is within the box. ok is
+// false if there is no such index. This is the BIGMIN operation, which allows a scan over
+// sorted keys to jump straight back into the box once it has stepped outside.
+func (s *Morton) NextInBox(t int, box Box) (next int, ok bool) {
+	return nextInBox(s, t, box)
+}
+
+// PrevInBox returns the largest index, no greater than t, whose cell is within the box. ok is
+// false if there is no such index. This is the LITMAX operation, the reverse of NextInBox.
+func (s *Morton) PrevInBox(t int, box Box) (prev int, ok bool) {
+	return prevInBox(s, t, box)
+}
+
+// nextInBox implements NextInBox for a curve which covers each aligned square with a
+// contiguous range.
+func nextInBox(curve SpaceFilling, t int, box Box) (int, bool) {
+	q, _ := newQuadtree(curve)
+	box = box.clip(q.n)
+	if box.empty() || t >= q.n*q.n {
+		return -1, false
+	}
+	if t < 0 {
+		t = 0
+	}
+	return q.findNext(box, q.root(), t)
+}
+
+// findNext returns the smallest index, no less than t, within both the box and the quadrant p.
+func (q *quadtree) findNext(box Box, p quadrant, t int) (int, bool) {
+	if p.t+p.n*p.n-1 < t || !box.overlaps(p.x, p.y, p.n) {
+		return -1, false
+	}
+
+	if box.contains(p.x, p.y, p.n) {
+		if p.t > t {
+			return p.t, true
+		}
+		return t, true
+	}
+
+	// Skip the quadrants which end before t.
+	size := p.n * p.n / 4
+	d := 0
+	if t > p.t {
+		d = (t - p.t) / size
+	}
+	for ; d < 4; d++ {
+		if next, ok := q.findNext(box, q.child(p, d), t); ok {
+			return next, true
+		}
+	}
+	return -1, false
+}
+
+// prevInBox implements PrevInBox for a curve which covers each aligned square with a
+// contiguous range.
+func prevInBox(curve SpaceFilling, t int, box Box) (int, bool) {
+	q, _ := newQuadtree(curve)
+	box = box.clip(q.n)
+	if box.empty() || t < 0 {
+		return -1, false
+	}
+	if t >= q.n*q.n {
+		t = q.n*q.n - 1
+	}
+	return q.findPrev(box, q.root(), t)
+}
+
+// findPrev returns the largest index, no greater than t, within both the box and the quadrant p.
+func (q *quadtree) findPrev(box Box, p quadrant, t int) (int, bool) {
+	if p.t > t || !box.overlaps(p.x, p.y, p.n) {
+		return -1, false
+	}
+
+	if box.contains(p.x, p.y, p.n) {
+		if end := p.t + p.n*p.n - 1; end < t {
+			return end, true
+		}
+		return t, true
+	}
+
+	// Skip the quadrants which start after t.
+	size := p.n * p.n / 4
+	d := 3
+	if end := p.t + p.n*p.n - 1; t < end {
+		d = (t - p.t) / size
+	}
+	for ; d >= 0; d-- {
+		if prev, ok := q.findPrev(box, q.child(p, d), t); ok {
+			return prev, true
+		}
+	}
+	return -1, false
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// inBoxCurve is a curve supporting NextInBox and PrevInBox.
+type inBoxCurve interface {
+	SpaceFilling
+	NextInBox(t int, box Box) (int, bool)
+	PrevInBox(t int, box Box) (int, bool)
+}
+
+// checkInBox compares NextInBox and PrevInBox, for every t, against a brute force search.
+func checkInBox(t *testing.T, name string, curve inBoxCurve, box Box) {
+	n, _ := curve.GetDimensions()
+	size := n * n
+
+	inside := make([]bool, size)
+	for d := range inside {
+		x, y, _ := curve.Map(d)
+		inside[d] = x >= box.XMin && x <= box.XMax && y >= box.YMin && y <= box.YMax
+	}
+
+	// wantNext[d] is the answer for d, and -1 if there is none.
+	wantNext := make([]int, size+1)
+	wantNext[size] = -1
+	for d := size - 1; d >= 0; d-- {
+		wantNext[d] = wantNext[d+1]
+		if inside[d] {
+			wantNext[d] = d
+		}
+	}
+	wantPrev := -1
+
+	for d := -1; d <= size; d++ {
+		want := wantNext[0]
+		if d >= 0 {
+			want = wantNext[d]
+		}
+		got, ok := curve.NextInBox(d, box)
+		if ok != (want >= 0) || (ok && got != want) {
+			t.Errorf("%s N=%d: NextInBox(%d, %+v) = (%d, %t) want %d", name, n, d, box, got, ok, want)
+		}
+
+		if d >= 0 && d < size && inside[d] {
+			wantPrev = d
+		}
+		want = wantPrev
+		if d < 0 {
+			want = -1
+		}
+		got, ok = curve.PrevInBox(d, box)
+		if ok != (want >= 0) || (ok && got != want) {
+			t.Errorf("%s N=%d: PrevInBox(%d, %+v) = (%d, %t) want %d", name, n, d, box, got, ok, want)
+		}
+	}
+}
+
+func inBoxCurves(t *testing.T, n int) map[string]inBoxCurve {
+	h, err := NewHilbert(n)
+	if err != nil {
+		t.Fatalf("NewHilbert(%d) failed: %s", n, err)
+	}
+	m, err := NewMorton(n)
+	if err != nil {
+		t.Fatalf("NewMorton(%d) failed: %s", n, err)
+	}
+	return map[string]inBoxCurve{"Hilbert": h, "Morton": m}
+}
+
+func TestInBoxAllBoxes(t *testing.T) {
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		var coords []int
+		for i := 0; i < n; i++ {
+			coords = append(coords, i)
+		}
+		checkInBoxes(t, n, coords)
+	}
+}
+
+func TestInBoxDenseBoxes(t *testing.T) {
+	// Every box whose edges are at the edges of aligned squares of width step.
+	for _, tc := range []struct{ n, step int }{{32, 8}, {64, 16}} {
+		var coords []int
+		for i := 0; i < tc.n; i++ {
+			if i%tc.step == 0 || i%tc.step == tc.step-1 {
+				coords = append(coords, i)
+			}
+		}
+		checkInBoxes(t, tc.n, coords)
+	}
+}
+
+// checkInBoxes checks every box of a space of width n whose edges are at the coordinates.
+func checkInBoxes(t *testing.T, n int, coords []int) {
+	for name, curve := range inBoxCurves(t, n) {
+		for i, xmin := range coords {
+			for j, ymin := range coords {
+				for _, xmax := range coords[i:] {
+					for _, ymax := range coords[j:] {
+						checkInBox(t, name, curve, Box{xmin, ymin, xmax, ymax})
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestInBoxRandomBoxes(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{128, 256} {
+		for name, curve := range inBoxCurves(t, n) {
+			for i := 0; i < 20; i++ {
+				xmin, ymin := r.Intn(n), r.Intn(n)
+				xmax, ymax := xmin+r.Intn(n-xmin), ymin+r.Intn(n-ymin)
+				checkInBox(t, name, curve, Box{xmin, ymin, xmax, ymax})
+			}
+		}
+	}
+}
+
+func TestInBoxOutsideSpace(t *testing.T) {
+	s, err := NewHilbert(16)
+	if err != nil {
+		t.Fatalf("NewHilbert(16) failed: %s", err)
+	}
+
+	checkInBox(t, "Hilbert", s, Box{-5, -5, 3, 20})
+
+	for _, box := range []Box{{16, 0, 20, 15}, {-4, -4, -1, -1}, {5, 5, 4, 4}} {
+		if got, ok := s.NextInBox(0, box); ok {
+			t.Errorf("NextInBox(0, %+v) = %d want none", box, got)
+		}
+		if got, ok := s.PrevInBox(255, box); ok {
+			t.Errorf("PrevInBox(255, %+v) = %d want none", box, got)
+		}
+	}
+}
+
+func BenchmarkNextInBox(b *testing.B) {
+	s, err := NewHilbert(1 << 16)
+	if err != nil {
+		b.Fatalf("NewHilbert(%d) failed: %s", 1<<16, err)
+	}
+	box := Box{1000, 2000, 5000, 3000}
+	for i := 0; i < b.N; i++ {
+		s.NextInBox(i*12345, box)
+	}
+}
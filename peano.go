@@ -35,7 +35,7 @@ func isPow3(n float64) bool {
 }
 
 // NewPeano returns a new Peano space filling curve which maps integers to and from the curve.
-// n must be a power of three.
+// n must be a power of three, and n^2 must fit in an int.
 func NewPeano(n int) (*Peano, error) {
 	if n <= 0 {
 		return nil, ErrNotPositive
@@ -45,6 +45,11 @@ func NewPeano(n int) (*Peano, error) {
 		return nil, ErrNotPowerOfThree
 	}
 
+	// Test if n^2, the size of the space, fits in an int
+	if n > maxInt/n {
+		return nil, ErrOverflow
+	}
+
 	return &Peano{
 		N: n,
 	}, nil
@@ -55,7 +60,7 @@ func (p *Peano) GetDimensions() (int, int) {
 	return p.N, p.N
 }
 
-// Map transforms a one dimension value, t, in the range [0, n^3-1] to coordinates on the Peano
+// Map transforms a one dimension value, t, in the range [0, n^2-1] to coordinates on the Peano
 // curve in the two-dimension space, where x and y are within [0,n-1].
 func (p *Peano) Map(t int) (x, y int, err error) {
 	if t < 0 || t >= p.N*p.N {
@@ -120,12 +125,177 @@ func (p *Peano) rotate(n, x, y, s int) (int, int) {
 }
 
 // MapInverse transform coordinates on the Peano curve from (x,y) to t.
-// NOT IMPLEMENTED YET
 func (p *Peano) MapInverse(x, y int) (t int, err error) {
 	if x < 0 || x >= p.N || y < 0 || y >= p.N {
 		return -1, ErrOutOfRange
 	}
 
-	panic("Not finished")
-	return -1, nil
+	for i := p.N / 3; i > 0; i = i / 3 {
+		// rx/ry are the coordinates in the 3x3 grid
+		rx := x / i
+		ry := y / i
+		if rx == 1 {
+			ry = 2 - ry
+		}
+		s := rx*3 + ry
+
+		t = t*9 + s
+
+		// Remove this level's offset, and undo the rotation Map applied. Each
+		// rotation is just a combination of flips, so it is its own inverse.
+		x, y = p.rotate(i, x%i, y%i, s)
+	}
+
+	return t, nil
+}
+
+// Lookup tables for each of the nine squares in the 3x3 grid, indexed by the value of the base 9
+// digit of t, describing the square's position and whether it is flipped by rotate.
+var (
+	peanoX     = [9]uint32{0, 0, 0, 1, 1, 1, 2, 2, 2}
+	peanoY     = [9]uint32{0, 1, 2, 2, 1, 0, 0, 1, 2}
+	peanoFlipX = [9]bool{false, true, false, false, true, false, false, true, false}
+	peanoFlipY = [9]bool{false, false, false, true, true, true, false, false, false}
+)
+
+// peanoDigit is the inverse of peanoX and peanoY, and is indexed by x*3+y.
+var peanoDigit = [9]uint64{0, 1, 2, 5, 4, 3, 6, 7, 8}
+
+// The batch methods step through two levels of the curve, one 9x9 grid, at a time. A flip
+// complements each base 3 digit of a coordinate, so the flips of successive levels combine by
+// xoring them, and a coordinate of the lower levels is flipped with (x ^ mask) + (i & mask),
+// where mask is all ones, which is i-1-x.
+var peanoPairs, peanoPairsInverse = newPeanoPairs()
+
+// peanoPair is the position within the 9x9 grid of two base 9 digits of t, and the masks
+// flipping the levels below them.
+type peanoPair struct {
+	x, y         uint32
+	flipX, flipY uint32
+}
+
+// newPeanoPairs builds the tables for the batch methods. The first is indexed by two base 9
+// digits of t. The second is indexed by the flips of the levels above, as flipX<<1 | flipY,
+// then the position within the 9x9 grid, x*9+y, and holds the two digits of t, then the flips
+// of the levels below.
+func newPeanoPairs() (pairs [81]peanoPair, inverse [4 * 81]uint32) {
+	mask := func(flip bool) uint32 {
+		if flip {
+			return ^uint32(0)
+		}
+		return 0
+	}
+
+	for hi := 0; hi < 9; hi++ {
+		for lo := 0; lo < 9; lo++ {
+			x, y := peanoX[lo], peanoY[lo]
+			if peanoFlipX[hi] {
+				x = 2 - x
+			}
+			if peanoFlipY[hi] {
+				y = 2 - y
+			}
+			x += 3 * peanoX[hi]
+			y += 3 * peanoY[hi]
+			flipX := peanoFlipX[hi] != peanoFlipX[lo]
+			flipY := peanoFlipY[hi] != peanoFlipY[lo]
+
+			pairs[hi*9+lo] = peanoPair{x, y, mask(flipX), mask(flipY)}
+
+			for state := uint32(0); state < 4; state++ {
+				// The position as seen from above is flipped by the levels above.
+				px, py := x, y
+				if state&2 != 0 {
+					px = 8 - px
+				}
+				if state&1 != 0 {
+					py = 8 - py
+				}
+				next := state ^ b2u(flipX)<<1 ^ b2u(flipY)
+				inverse[state*81+px*9+py] = uint32(hi*9+lo) | next<<8
+			}
+		}
+	}
+	return pairs, inverse
+}
+
+// b2u returns 1 if b is true, otherwise 0.
+func b2u(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// MapBatch transforms each value in ts to coordinates on the Peano curve, storing them in the
+// same position in xs and ys. The slices must be the same length. If any value is out of range
+// ErrOutOfRange is returned, and the coordinates of the values before it are still stored.
+func (p *Peano) MapBatch(ts []uint64, xs, ys []uint32) error {
+	if len(xs) != len(ts) || len(ys) != len(ts) {
+		return ErrLengthMismatch
+	}
+	xs, ys = xs[:len(ts)], ys[:len(ts)]
+
+	n := uint32(p.N)
+	size := uint64(p.N) * uint64(p.N)
+
+	for j, t := range ts {
+		if t >= size {
+			return ErrOutOfRange
+		}
+
+		// The same as Map, two levels at a time. When there is an odd number of levels, the
+		// last pair has a leading zero digit, which is the unflipped square at (0,0).
+		var x, y uint32
+		for i := uint32(1); i < n; i = i * 9 {
+			pair := &peanoPairs[t%81]
+			x = (x ^ pair.flipX) + (i & pair.flipX) + pair.x*i
+			y = (y ^ pair.flipY) + (i & pair.flipY) + pair.y*i
+			t /= 81
+		}
+		xs[j], ys[j] = x, y
+	}
+	return nil
+}
+
+// MapInverseBatch transforms each pair of coordinates in xs and ys to a value on the Peano
+// curve, storing them in the same position in ts. The slices must be the same length. If any
+// pair is out of range ErrOutOfRange is returned, and the values of the pairs before it are
+// still stored.
+func (p *Peano) MapInverseBatch(xs, ys []uint32, ts []uint64) error {
+	if len(ys) != len(xs) || len(ts) != len(xs) {
+		return ErrLengthMismatch
+	}
+	ys, ts = ys[:len(xs)], ts[:len(xs)]
+
+	n := uint32(p.N)
+
+	// Each coordinate is at most 20 base 3 digits, or 10 base 9 digits.
+	var digits [10]uint32
+
+	for j, x := range xs {
+		y := ys[j]
+		if x >= n || y >= n {
+			return ErrOutOfRange
+		}
+
+		// The same as MapInverse, two levels at a time. The base 9 digits of x and y are found
+		// from the bottom, then the curve is walked from the top.
+		levels := 0
+		for i := uint32(1); i < n; i = i * 9 {
+			digits[levels] = (x%9)*9 + y%9
+			x, y = x/9, y/9
+			levels++
+		}
+
+		var t uint64
+		var state uint32
+		for l := levels - 1; l >= 0; l-- {
+			e := peanoPairsInverse[state*81+digits[l]]
+			t = t*81 + uint64(e&0xff)
+			state = e >> 8
+		}
+		ts[j] = t
+	}
+	return nil
 }
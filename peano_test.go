@@ -80,32 +80,30 @@ func TestPeanoMapRangeErrors(t *testing.T) {
 	}
 }
 
-/*
 func TestPeanoMapInverseRangeErrors(t *testing.T) {
 	var mapInverseRangeTestCases = []struct {
 		x, y    int
 		wantErr error
 	}{
 		{0, 0, nil},
-		{15, 15, nil},
+		{8, 8, nil},
 		{-1, 0, ErrOutOfRange},
 		{0, -1, ErrOutOfRange},
-		{16, 0, ErrOutOfRange},
-		{0, 16, ErrOutOfRange},
+		{9, 0, ErrOutOfRange},
+		{0, 9, ErrOutOfRange},
 	}
 
-	s, err := New(16)
+	s, err := NewPeano(9)
 	if err != nil {
-		t.Fatalf("Failed to create hibert space: %s", err)
+		t.Fatalf("NewPeano(9) failed: %s", err)
 	}
 
 	for _, tc := range mapInverseRangeTestCases {
 		if _, err = s.MapInverse(tc.x, tc.y); err != tc.wantErr {
-			t.Errorf("MapInverse(%d, %d) did not fail, want %q, got %q", tc.x, tc.y, tc.wantErr, err)
+			t.Errorf("MapInverse(%d, %d) = %q want %q", tc.x, tc.y, err, tc.wantErr)
 		}
 	}
 }
-*/
 
 func TestPeanoSmallMap(t *testing.T) {
 	s, err := NewPeano(1)
@@ -121,16 +119,13 @@ func TestPeanoSmallMap(t *testing.T) {
 		t.Errorf("Map(0) = (%d, %d) want (0, 0)", x, y)
 	}
 
-	/*
-		// TODO Test when MapInverse is implemented
-		d, err := s.MapInverse(0, 0)
-		if err != nil {
-			t.Errorf("MapInverse(0,0) returned error: %s", err)
-		}
-		if d != 0 {
-			t.Errorf("MapInverse(0, 0) failed, want 0, got %d", d)
-		}
-	*/
+	d, err := s.MapInverse(0, 0)
+	if err != nil {
+		t.Errorf("MapInverse(0, 0) returned error: %s", err)
+	}
+	if d != 0 {
+		t.Errorf("MapInverse(0, 0) = %d want 0", d)
+	}
 }
 
 func TestPeanoMap(t *testing.T) {
@@ -150,50 +145,51 @@ func TestPeanoMap(t *testing.T) {
 	}
 }
 
-/*
 func TestPeanoMapInverse(t *testing.T) {
-	s, err := New(16)
+	s, err := NewPeano(9)
 	if err != nil {
-		t.Fatalf("Failed to create hibert space: %s", err)
+		t.Fatalf("NewPeano(9) failed: %s", err)
 	}
 
-	for _, tc := range testCases {
+	for _, tc := range peanoTestCases {
 		d, err := s.MapInverse(tc.x, tc.y)
 		if err != nil {
 			t.Errorf("MapInverse(%d, %d) returned error: %s", tc.x, tc.y, err)
 		}
 		if d != tc.d {
-			t.Errorf("MapInverse(%d, %d) failed, want %d, got %d", tc.x, tc.y, tc.d, d)
+			t.Errorf("MapInverse(%d, %d) = %d want %d", tc.x, tc.y, d, tc.d)
 		}
 	}
 }
 
 func TestPeanoAllMapValues(t *testing.T) {
-	s, err := New(16)
-	if err != nil {
-		t.Fatalf("Failed to create hibert space: %s", err)
-	}
-
-	for d := 0; d < s.N*s.N; d++ {
-		// Map forwards and then back
-		x, y, err := s.Map(d)
+	for _, n := range []int{1, 3, 9, 27, 81} {
+		s, err := NewPeano(n)
 		if err != nil {
-			t.Errorf("Map(%d) returned error: %s", d, err)
-		}
-		if x < 0 || x >= s.N || y < 0 || y >= s.N {
-			t.Errorf("Map(%d) returned x,y out of range: (%d, %d)", d, x, y)
+			t.Fatalf("NewPeano(%d) failed: %s", n, err)
 		}
 
-		dPrime, err := s.MapInverse(x, y)
-		if err != nil {
-			t.Errorf("MapInverse(%d, %d) returned error: %s", x, y, err)
-		}
-		if d != dPrime {
-			t.Errorf("Failed Map(%d) -> MapInverse(%d, %d) -> %d", d, x, y, dPrime)
+		for d := 0; d < s.N*s.N; d++ {
+			// Map forwards and then back
+			x, y, err := s.Map(d)
+			if err != nil {
+				t.Errorf("N=%d: Map(%d) returned error: %s", n, d, err)
+			}
+			if x < 0 || x >= s.N || y < 0 || y >= s.N {
+				t.Errorf("N=%d: Map(%d) returned x,y out of range: (%d, %d)", n, d, x, y)
+			}
+
+			dPrime, err := s.MapInverse(x, y)
+			if err != nil {
+				t.Errorf("N=%d: MapInverse(%d, %d) returned error: %s", n, x, y, err)
+			}
+			if d != dPrime {
+				t.Errorf("N=%d: Failed Map(%d) -> MapInverse(%d, %d) -> %d", n, d, x, y, dPrime)
+			}
 		}
 	}
 }
-*/
+
 func BenchmarkPeanoMap(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		s, err := NewPeano(peanoBenchmarkN)
@@ -219,22 +215,20 @@ func BenchmarkPeanoMapRandom(b *testing.B) {
 	}
 }
 
-/*
 func BenchmarkPeanoMapInverse(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		s, err := New(benchmarkN)
+		s, err := NewPeano(peanoBenchmarkN)
 		if err != nil {
-			b.Fatalf("Failed to create hibert space: %s", err)
+			b.Fatalf("NewPeano(%d) failed: %s", peanoBenchmarkN, err)
 		}
 
-		for x := 0; x < benchmarkN; x++ {
-			for y := 0; y < benchmarkN; y++ {
+		for x := 0; x < peanoBenchmarkN; x++ {
+			for y := 0; y < peanoBenchmarkN; y++ {
 				s.MapInverse(x, y)
 			}
 		}
 	}
 }
-*/
 
 func TestIsPow3(t *testing.T) {
 	testCases := []struct {
@@ -263,3 +257,140 @@ func TestIsPow3(t *testing.T) {
 		}
 	}
 }
+
+func TestPeanoMapBatch(t *testing.T) {
+	for _, n := range []int{1, 3, 9, 27, 81} {
+		s, err := NewPeano(n)
+		if err != nil {
+			t.Fatalf("NewPeano(%d) failed: %s", n, err)
+		}
+
+		size := s.N * s.N
+		ts := make([]uint64, size)
+		for d := range ts {
+			ts[d] = uint64(d)
+		}
+		xs, ys := make([]uint32, size), make([]uint32, size)
+
+		if err := s.MapBatch(ts, xs, ys); err != nil {
+			t.Fatalf("N=%d: MapBatch returned error: %s", n, err)
+		}
+		for d := range ts {
+			x, y, _ := s.Map(d)
+			if xs[d] != uint32(x) || ys[d] != uint32(y) {
+				t.Errorf("N=%d: MapBatch(%d) = (%d, %d) want (%d, %d)", n, d, xs[d], ys[d], x, y)
+			}
+		}
+
+		tsPrime := make([]uint64, size)
+		if err := s.MapInverseBatch(xs, ys, tsPrime); err != nil {
+			t.Fatalf("N=%d: MapInverseBatch returned error: %s", n, err)
+		}
+		for d := range ts {
+			if tsPrime[d] != ts[d] {
+				t.Errorf("N=%d: MapInverseBatch(%d, %d) = %d want %d", n, xs[d], ys[d], tsPrime[d], ts[d])
+			}
+		}
+	}
+}
+
+// TestPeanoMapBatchLarge checks random values on the largest Peano space against Map, with an
+// odd number of levels.
+func TestPeanoMapBatchLarge(t *testing.T) {
+	n := 1162261467 // 3^19
+	if uintSize == 32 {
+		n = 19683 // 3^9
+	}
+	s, err := NewPeano(n)
+	if err != nil {
+		t.Fatalf("NewPeano(%d) failed: %s", n, err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	ts := make([]uint64, 1000)
+	for i := range ts {
+		ts[i] = uint64(r.Int63n(int64(n) * int64(n)))
+	}
+	xs, ys := make([]uint32, len(ts)), make([]uint32, len(ts))
+
+	if err := s.MapBatch(ts, xs, ys); err != nil {
+		t.Fatalf("MapBatch returned error: %s", err)
+	}
+	for i, d := range ts {
+		x, y, _ := s.Map(int(d))
+		if xs[i] != uint32(x) || ys[i] != uint32(y) {
+			t.Errorf("MapBatch(%d) = (%d, %d) want (%d, %d)", d, xs[i], ys[i], x, y)
+		}
+	}
+
+	tsPrime := make([]uint64, len(ts))
+	if err := s.MapInverseBatch(xs, ys, tsPrime); err != nil {
+		t.Fatalf("MapInverseBatch returned error: %s", err)
+	}
+	for i := range ts {
+		if tsPrime[i] != ts[i] {
+			t.Errorf("MapInverseBatch(%d, %d) = %d want %d", xs[i], ys[i], tsPrime[i], ts[i])
+		}
+	}
+}
+
+func TestPeanoMapBatchErrors(t *testing.T) {
+	s, err := NewPeano(9)
+	if err != nil {
+		t.Fatalf("NewPeano(9) failed: %s", err)
+	}
+
+	var testCases = []struct {
+		ts      []uint64
+		xs, ys  []uint32
+		wantErr error
+	}{
+		{[]uint64{0, 80}, []uint32{0, 8}, []uint32{0, 0}, nil},
+		{[]uint64{0, 81}, []uint32{0, 9}, []uint32{0, 0}, ErrOutOfRange},
+		{[]uint64{0, 1}, []uint32{0}, []uint32{0, 0}, ErrLengthMismatch},
+		{[]uint64{0}, []uint32{0, 0}, []uint32{0, 0}, ErrLengthMismatch},
+	}
+
+	for _, tc := range testCases {
+		if err := s.MapBatch(tc.ts, tc.xs, tc.ys); err != tc.wantErr {
+			t.Errorf("MapBatch(%v) = %q want %q", tc.ts, err, tc.wantErr)
+		}
+		if err := s.MapInverseBatch(tc.xs, tc.ys, tc.ts); err != tc.wantErr {
+			t.Errorf("MapInverseBatch(%v, %v) = %q want %q", tc.xs, tc.ys, err, tc.wantErr)
+		}
+	}
+}
+
+func BenchmarkPeanoMapBatch(b *testing.B) {
+	ts := make([]uint64, peanoBenchmarkN*peanoBenchmarkN)
+	for d := range ts {
+		ts[d] = uint64(d)
+	}
+	xs, ys := make([]uint32, len(ts)), make([]uint32, len(ts))
+
+	for i := 0; i < b.N; i++ {
+		s, err := NewPeano(peanoBenchmarkN)
+		if err != nil {
+			b.Fatalf("NewPeano(%d) failed: %s", peanoBenchmarkN, err)
+		}
+		s.MapBatch(ts, xs, ys)
+	}
+}
+
+func BenchmarkPeanoMapInverseBatch(b *testing.B) {
+	xs, ys := make([]uint32, peanoBenchmarkN*peanoBenchmarkN), make([]uint32, peanoBenchmarkN*peanoBenchmarkN)
+	for x := 0; x < peanoBenchmarkN; x++ {
+		for y := 0; y < peanoBenchmarkN; y++ {
+			xs[x*peanoBenchmarkN+y], ys[x*peanoBenchmarkN+y] = uint32(x), uint32(y)
+		}
+	}
+	ts := make([]uint64, len(xs))
+
+	for i := 0; i < b.N; i++ {
+		s, err := NewPeano(peanoBenchmarkN)
+		if err != nil {
+			b.Fatalf("NewPeano(%d) failed: %s", peanoBenchmarkN, err)
+		}
+		s.MapInverseBatch(xs, ys, ts)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import "sort"
+
+// Range is an inclusive range of indexes on a curve.
+type Range struct {
+	Start, End int
+}
+
+// Box is an axis-aligned box of cells, with corners (XMin,YMin) and (XMax,YMax) inclusive.
+type Box struct {
+	XMin, YMin, XMax, YMax int
+}
+
+// contains returns true if the square of width n, with corner (x,y), is within the box.
+func (b Box) contains(x, y, n int) bool {
+	return x >= b.XMin && y >= b.YMin && x+n-1 <= b.XMax && y+n-1 <= b.YMax
+}
+
+// clip returns the part of the box within a space of width n.
+func (b Box) clip(n int) Box {
+	if b.XMin < 0 {
+		b.XMin = 0
+	}
+	if b.YMin < 0 {
+		b.YMin = 0
+	}
+	if b.XMax > n-1 {
+		b.XMax = n - 1
+	}
+	if b.YMax > n-1 {
+		b.YMax = n - 1
+	}
+	return b
+}
+
+// empty returns true if the box contains no cells.
+func (b Box) empty() bool {
+	return b.XMin > b.XMax || b.YMin > b.YMax
+}
+
+// overlaps returns true if any of the square of width n, with corner (x,y), is within the box.
+func (b Box) overlaps(x, y, n int) bool {
+	return x <= b.XMax && y <= b.YMax && x+n-1 >= b.XMin && y+n-1 >= b.YMin
+}
+
+// Ranges returns the ranges of indexes on the curve that cover the box with corners
+// (xmin,ymin) and (xmax,ymax) inclusive, in increasing order. This is useful for turning a box
+// query into range scans over keys sorted by their index on the curve.
+//
+// The curve must be a Hilbert, Morton or Moore curve. In each of these every aligned square,
+// whose width is a power of two, covers one contiguous range of indexes, so the box is covered
+// by splitting the space into quadrants, in the same way as MapInverse, until each quadrant is
+// either wholly inside or outside the box. The quadrants are split a level at a time.
+//
+// Without a limit the ranges exactly cover the box, and adjacent ranges are always joined. If
+// maxRanges is greater than zero, splitting stops at the first level which needs more than
+// maxRanges ranges, even when the quadrants crossing the edge of the box are included whole.
+// The ranges separated by the smallest gaps at that level are then merged until only
+// maxRanges remain. The result also covers the cells in those gaps and in the quadrants
+// crossing the edge of the box, but the work done depends on maxRanges rather than the size
+// of the box.
+//
+// The box is clipped to the space, and no ranges are returned if it is empty.
+func Ranges(curve SpaceFilling, xmin, ymin, xmax, ymax, maxRanges int) ([]Range, error) {
+	q, ok := newQuadtree(curve)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	box := Box{xmin, ymin, xmax, ymax}.clip(q.n)
+	if box.empty() {
+		return nil, nil
+	}
+
+	// Each level holds, in curve order, the quadrants inside the box and those crossing its
+	// edge. The ranges of a level cover the crossing quadrants whole.
+	level := []boxQuadrant{{q.root(), false}}
+	for {
+		next, done := q.splitLevel(level, box)
+		if maxRanges > 0 {
+			if r := levelRanges(next); len(r) > maxRanges {
+				return mergeRanges(r, maxRanges), nil
+			}
+		}
+		level = next
+		if done {
+			return levelRanges(level), nil
+		}
+	}
+}
+
+// boxQuadrant is a quadrant which is within a box, or crosses its edge.
+type boxQuadrant struct {
+	quadrant
+	inside bool
+}
+
+// splitLevel splits each of the quadrants crossing the edge of the box, and returns those of
+// their children which are within the box or cross its edge. done is true if none do.
+func (q *quadtree) splitLevel(level []boxQuadrant, box Box) (next []boxQuadrant, done bool) {
+	done = true
+	for _, bq := range level {
+		if bq.inside {
+			next = append(next, bq)
+			continue
+		}
+		for _, child := range q.split(bq.quadrant) {
+			if !box.overlaps(child.x, child.y, child.n) {
+				continue // outside
+			}
+			inside := box.contains(child.x, child.y, child.n)
+			done = done && inside
+			next = append(next, boxQuadrant{child, inside})
+		}
+	}
+	return next, done
+}
+
+// levelRanges returns the ranges covering the quadrants.
+func levelRanges(level []boxQuadrant) []Range {
+	r := &ranger{}
+	for _, bq := range level {
+		r.add(bq.t, bq.t+bq.n*bq.n-1)
+	}
+	return r.ranges
+}
+
+// ranger joins ranges as they are added in increasing order.
+type ranger struct {
+	ranges []Range
+}
+
+// add appends the range [start, end], joining it to the previous range if they touch.
+func (r *ranger) add(start, end int) {
+	if last := len(r.ranges) - 1; last >= 0 && r.ranges[last].End+1 == start {
+		r.ranges[last].End = end
+		return
+	}
+	r.ranges = append(r.ranges, Range{start, end})
+}
+
+// quadtree splits the aligned squares of a curve into quadrants, in the order the curve visits
+// them. Each quadrant carries the orientation of the curve within it, so splitting one takes
+// constant time.
+type quadtree struct {
+	n    int          // Width of the space.
+	top  *curveLayout // Layout of the whole space.
+	rest *curveLayout // Layout of each smaller square.
+}
+
+// quadrant is an aligned square of width n, whose indexes start at t, and the orientation of
+// the curve within it.
+type quadrant struct {
+	x, y, n, t int
+	orient     int
+}
+
+// newQuadtree returns the quadtree of the curve, and false if the curve does not cover each
+// aligned square with one contiguous range.
+func newQuadtree(curve SpaceFilling) (*quadtree, bool) {
+	switch c := curve.(type) {
+	case *Hilbert:
+		return &quadtree{c.N, hilbertLayout, hilbertLayout}, true
+	case *Morton:
+		return &quadtree{c.N, mortonLayout, mortonLayout}, true
+	case *Moore:
+		return &quadtree{c.N, mooreLayout, hilbertLayout}, true
+	}
+	return nil, false
+}
+
+// root returns the quadrant of the whole space.
+func (q *quadtree) root() quadrant {
+	return quadrant{0, 0, q.n, 0, 0}
+}
+
+// split returns the four quadrants of p in the order the curve visits them. p must be wider
+// than one cell.
+func (q *quadtree) split(p quadrant) [4]quadrant {
+	var quadrants [4]quadrant
+	for d := range quadrants {
+		quadrants[d] = q.child(p, d)
+	}
+	return quadrants
+}
+
+// child returns the quadrant of p which the curve visits d'th. p must be wider than one cell.
+func (q *quadtree) child(p quadrant, d int) quadrant {
+	layout := q.rest
+	if p.n == q.n {
+		layout = q.top
+	}
+	sq := &layout.squares[p.orient][d]
+	n := p.n / 2
+	return quadrant{
+		x:      p.x + sq.col*n,
+		y:      p.y + sq.row*n,
+		n:      n,
+		t:      p.t + d*n*n,
+		orient: sq.orient,
+	}
+}
+
+// mergeRanges merges the ranges separated by the smallest gaps, until only maxRanges remain.
+func mergeRanges(ranges []Range, maxRanges int) []Range {
+	// Find the largest gaps, which are the ones to keep.
+	gaps := make([]int, len(ranges)-1)
+	for i := range gaps {
+		gaps[i] = i
+	}
+	sort.SliceStable(gaps, func(i, j int) bool {
+		return gapSize(ranges, gaps[i]) > gapSize(ranges, gaps[j])
+	})
+	keep := make([]bool, len(ranges)-1)
+	for _, i := range gaps[:maxRanges-1] {
+		keep[i] = true
+	}
+
+	merged := make([]Range, 0, maxRanges)
+	current := ranges[0]
+	for i, keepGap := range keep {
+		if keepGap {
+			merged = append(merged, current)
+			current = ranges[i+1]
+		} else {
+			current.End = ranges[i+1].End
+		}
+	}
+	return append(merged, current)
+}
+
+// gapSize returns the number of indexes between range i and i+1.
+func gapSize(ranges []Range, i int) int {
+	return ranges[i+1].Start - ranges[i].End - 1
+}
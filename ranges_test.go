@@ -0,0 +1,267 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// bruteForceRanges returns the exact ranges covering the box, by checking every cell.
+func bruteForceRanges(curve SpaceFilling, xmin, ymin, xmax, ymax int) []Range {
+	var ts []int
+	for x := xmin; x <= xmax; x++ {
+		for y := ymin; y <= ymax; y++ {
+			if t, err := curve.MapInverse(x, y); err == nil {
+				ts = append(ts, t)
+			}
+		}
+	}
+	sort.Ints(ts)
+
+	var ranges []Range
+	for _, t := range ts {
+		if len(ranges) > 0 && ranges[len(ranges)-1].End+1 == t {
+			ranges[len(ranges)-1].End = t
+		} else {
+			ranges = append(ranges, Range{t, t})
+		}
+	}
+	return ranges
+}
+
+func rangesCurves(t *testing.T, n int) map[string]SpaceFilling {
+	curves := map[string]SpaceFilling{}
+	var err error
+	if curves["Hilbert"], err = NewHilbert(n); err != nil {
+		t.Fatalf("NewHilbert(%d) failed: %s", n, err)
+	}
+	if curves["Morton"], err = NewMorton(n); err != nil {
+		t.Fatalf("NewMorton(%d) failed: %s", n, err)
+	}
+	if curves["Moore"], err = NewMoore(n); err != nil {
+		t.Fatalf("NewMoore(%d) failed: %s", n, err)
+	}
+	return curves
+}
+
+func TestRangesAllBoxes(t *testing.T) {
+	const n = 8
+	for name, curve := range rangesCurves(t, n) {
+		for xmin := 0; xmin < n; xmin++ {
+			for ymin := 0; ymin < n; ymin++ {
+				for xmax := xmin; xmax < n; xmax++ {
+					for ymax := ymin; ymax < n; ymax++ {
+						got, err := Ranges(curve, xmin, ymin, xmax, ymax, 0)
+						if err != nil {
+							t.Fatalf("%s: Ranges(%d, %d, %d, %d) returned error: %s", name, xmin, ymin, xmax, ymax, err)
+						}
+						want := bruteForceRanges(curve, xmin, ymin, xmax, ymax)
+						if !reflect.DeepEqual(got, want) {
+							t.Errorf("%s: Ranges(%d, %d, %d, %d) = %v want %v", name, xmin, ymin, xmax, ymax, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRangesClipped(t *testing.T) {
+	s, err := NewHilbert(16)
+	if err != nil {
+		t.Fatalf("NewHilbert(16) failed: %s", err)
+	}
+
+	var testCases = []struct {
+		xmin, ymin, xmax, ymax int
+		want                   []Range
+	}{
+		{-10, -10, 100, 100, []Range{{0, 255}}},
+		{-5, -5, -1, 3, nil},
+		{16, 0, 20, 15, nil},
+		{5, 5, 4, 4, nil},
+		{-3, -3, 0, 0, []Range{{0, 0}}},
+	}
+
+	for _, tc := range testCases {
+		got, err := Ranges(s, tc.xmin, tc.ymin, tc.xmax, tc.ymax, 0)
+		if err != nil {
+			t.Errorf("Ranges(%d, %d, %d, %d) returned error: %s", tc.xmin, tc.ymin, tc.xmax, tc.ymax, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Ranges(%d, %d, %d, %d) = %v want %v", tc.xmin, tc.ymin, tc.xmax, tc.ymax, got, tc.want)
+		}
+	}
+}
+
+func TestRangesNotSupported(t *testing.T) {
+	s, err := NewPeano(9)
+	if err != nil {
+		t.Fatalf("NewPeano(9) failed: %s", err)
+	}
+	if _, err := Ranges(s, 0, 0, 4, 4, 0); err != ErrNotSupported {
+		t.Errorf("Ranges(Peano) = %q want %q", err, ErrNotSupported)
+	}
+}
+
+func TestRangesMaxRanges(t *testing.T) {
+	const n = 64
+	r := rand.New(rand.NewSource(1))
+
+	for name, curve := range rangesCurves(t, n) {
+		for i := 0; i < 200; i++ {
+			xmin, ymin := r.Intn(n), r.Intn(n)
+			xmax, ymax := xmin+r.Intn(n-xmin), ymin+r.Intn(n-ymin)
+			maxRanges := 1 + r.Intn(8)
+
+			exact := bruteForceRanges(curve, xmin, ymin, xmax, ymax)
+			got, err := Ranges(curve, xmin, ymin, xmax, ymax, maxRanges)
+			if err != nil {
+				t.Fatalf("%s: Ranges(%d, %d, %d, %d, %d) returned error: %s", name, xmin, ymin, xmax, ymax, maxRanges, err)
+			}
+
+			if len(exact) <= maxRanges {
+				if !reflect.DeepEqual(got, exact) {
+					t.Errorf("%s: Ranges(%d, %d, %d, %d, %d) = %v want %v", name, xmin, ymin, xmax, ymax, maxRanges, got, exact)
+				}
+				continue
+			}
+
+			if len(got) != maxRanges {
+				t.Errorf("%s: Ranges(%d, %d, %d, %d, %d) returned %d ranges", name, xmin, ymin, xmax, ymax, maxRanges, len(got))
+			}
+
+			// Every exact range must be within one of the merged ranges.
+			for _, e := range exact {
+				found := false
+				for _, g := range got {
+					if g.Start <= e.Start && e.End <= g.End {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("%s: Ranges(%d, %d, %d, %d, %d) = %v does not cover %v", name, xmin, ymin, xmax, ymax, maxRanges, got, e)
+				}
+			}
+
+			// No merge of the exact ranges covers fewer extra cells than merging the smallest
+			// gaps between them.
+			var gaps []int
+			for j := 0; j < len(exact)-1; j++ {
+				gaps = append(gaps, gapSize(exact, j))
+			}
+			sort.Ints(gaps)
+			minExtra := 0
+			for _, gap := range gaps[:len(exact)-maxRanges] {
+				minExtra += gap
+			}
+
+			// The ranges must be no worse than covering the box with the aligned squares of the
+			// deepest level which needs at most maxRanges ranges.
+			var level []Range
+			for size := n; size >= 1; size /= 2 {
+				r := bruteForceLevelRanges(curve, n, size, Box{xmin, ymin, xmax, ymax})
+				if len(r) > maxRanges {
+					break
+				}
+				level = r
+			}
+			maxExtra := -(xmax - xmin + 1) * (ymax - ymin + 1)
+			for _, r := range level {
+				maxExtra += r.End - r.Start + 1
+			}
+
+			extra := -(xmax - xmin + 1) * (ymax - ymin + 1)
+			for _, r := range got {
+				extra += r.End - r.Start + 1
+			}
+			if extra < minExtra || extra > maxExtra {
+				t.Errorf("%s: Ranges(%d, %d, %d, %d, %d) covers %d extra cells want within [%d, %d]", name, xmin, ymin, xmax, ymax, maxRanges, extra, minExtra, maxExtra)
+			}
+		}
+	}
+}
+
+// bruteForceLevelRanges returns the ranges covering the aligned squares of width size which
+// overlap the box, by checking every cell.
+func bruteForceLevelRanges(curve SpaceFilling, n, size int, box Box) []Range {
+	r := &ranger{}
+	for t := 0; t < n*n; t++ {
+		x, y, _ := curve.Map(t)
+		if box.overlaps(x&^(size-1), y&^(size-1), size) {
+			r.add(t, t)
+		}
+	}
+	return r.ranges
+}
+
+func TestRangesLargeBoxBudget(t *testing.T) {
+	// With a budget, the work depends on the budget rather than the edge of the box, so this
+	// finishes quickly even though the exact ranges are many thousands.
+	n := 1 << 20
+	if uintSize == 32 {
+		n = 1 << 15
+	}
+	s, err := NewHilbert(n)
+	if err != nil {
+		t.Fatalf("NewHilbert(%d) failed: %s", n, err)
+	}
+	side := n / 16
+	got, err := Ranges(s, 12345, 23456, 12345+side-1, 23456+side-1, 8)
+	if err != nil {
+		t.Fatalf("Ranges returned error: %s", err)
+	}
+	if len(got) != 8 {
+		t.Errorf("Ranges returned %d ranges want 8", len(got))
+	}
+}
+
+func BenchmarkRanges(b *testing.B) {
+	s, err := NewHilbert(1 << 16)
+	if err != nil {
+		b.Fatalf("NewHilbert(%d) failed: %s", 1<<16, err)
+	}
+	for i := 0; i < b.N; i++ {
+		Ranges(s, 1000, 2000, 5000, 3000, 0)
+	}
+}
+
+func BenchmarkRangesMaxRanges(b *testing.B) {
+	s, err := NewHilbert(1 << 16)
+	if err != nil {
+		b.Fatalf("NewHilbert(%d) failed: %s", 1<<16, err)
+	}
+	for i := 0; i < b.N; i++ {
+		Ranges(s, 1000, 2000, 5000, 3000, 16)
+	}
+}
+
+func BenchmarkRangesMaxRangesLargeBox(b *testing.B) {
+	n := 1 << 20
+	if uintSize == 32 {
+		n = 1 << 15
+	}
+	s, err := NewHilbert(n)
+	if err != nil {
+		b.Fatalf("NewHilbert(%d) failed: %s", n, err)
+	}
+	side := n / 16
+	for i := 0; i < b.N; i++ {
+		Ranges(s, 12345, 23456, 12345+side-1, 23456+side-1, 8)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Packed is a static R-tree, packed into flat arrays, in the style of flatbush. Boxes are
+// added, then Finish sorts them by the Hilbert value of their centres and builds the tree
+// bottom up, filling every node but the last on each level. After that the tree can be
+// searched, but no more boxes can be added.
+type Packed struct {
+	nodeSize int
+
+	// The nodes of every level, from the leaves up to the root. A leaf's index is the item it
+	// holds, and an internal node's index is the position in boxes of its first child.
+	boxes   []Box
+	indices []int
+
+	levelBounds []int // The end of each level in boxes.
+	finished    bool
+}
+
+// NewPacked returns an empty packed R-tree, whose nodes hold up to nodeSize children.
+// nodeSize must be at least two, and 16 is a good default.
+func NewPacked(nodeSize int) (*Packed, error) {
+	if nodeSize < 2 {
+		return nil, ErrNodeSize
+	}
+	return &Packed{nodeSize: nodeSize}, nil
+}
+
+// Add adds a box to the tree, and returns its item number, which counts up from zero.
+func (t *Packed) Add(b Box) (int, error) {
+	if t.finished {
+		return -1, ErrFinished
+	}
+	t.indices = append(t.indices, len(t.boxes))
+	t.boxes = append(t.boxes, b)
+	return len(t.boxes) - 1, nil
+}
+
+// Len returns the number of items added to the tree.
+func (t *Packed) Len() int {
+	if len(t.levelBounds) > 0 {
+		return t.levelBounds[0]
+	}
+	return len(t.boxes)
+}
+
+// Finish builds the tree from the boxes added. Boxes whose centres have the same Hilbert value
+// are kept in the order they were added, so the same boxes always build the same tree.
+func (t *Packed) Finish() error {
+	if t.finished {
+		return ErrFinished
+	}
+	t.finished = true
+
+	n := len(t.boxes)
+	if n == 0 {
+		return nil
+	}
+
+	bounds := emptyBox
+	for _, b := range t.boxes {
+		bounds = bounds.extend(b)
+	}
+
+	// Sort the items along the curve.
+	values := make([]uint64, n)
+	for i, b := range t.boxes {
+		values[i] = hilbertValue(b, bounds)
+	}
+	sort.Sort(byValue{values, t.boxes, t.indices})
+
+	// Build each level from the one below, until a level has a single node.
+	t.levelBounds = []int{n}
+	for start, end := 0, n; end-start > 1; start, end = end, len(t.boxes) {
+		for i := start; i < end; i += t.nodeSize {
+			node := emptyBox
+			for j := i; j < i+t.nodeSize && j < end; j++ {
+				node = node.extend(t.boxes[j])
+			}
+			t.boxes = append(t.boxes, node)
+			t.indices = append(t.indices, i)
+		}
+		t.levelBounds = append(t.levelBounds, len(t.boxes))
+	}
+	return nil
+}
+
+// byValue sorts the boxes and indices by their Hilbert values, and then by the order they were
+// added.
+type byValue struct {
+	values  []uint64
+	boxes   []Box
+	indices []int
+}
+
+func (s byValue) Len() int { return len(s.values) }
+func (s byValue) Less(i, j int) bool {
+	if s.values[i] != s.values[j] {
+		return s.values[i] < s.values[j]
+	}
+	return s.indices[i] < s.indices[j]
+}
+func (s byValue) Swap(i, j int) {
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.boxes[i], s.boxes[j] = s.boxes[j], s.boxes[i]
+	s.indices[i], s.indices[j] = s.indices[j], s.indices[i]
+}
+
+// Search returns the items whose boxes intersect b. They are in the order of the tree, which is
+// along the curve.
+func (t *Packed) Search(b Box) ([]int, error) {
+	if !t.finished {
+		return nil, ErrNotFinished
+	}
+	if len(t.boxes) == 0 {
+		return nil, nil
+	}
+
+	var results []int
+
+	// Each entry is the position of a node, and its level. Children are pushed in reverse so
+	// they are visited in order.
+	type entry struct{ pos, level int }
+	root := len(t.boxes) - 1
+	stack := []entry{{root, len(t.levelBounds) - 1}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !b.Intersects(t.boxes[e.pos]) {
+			continue
+		}
+		if e.level == 0 {
+			results = append(results, t.indices[e.pos])
+			continue
+		}
+
+		first := t.indices[e.pos]
+		end := first + t.nodeSize
+		if end > t.levelBounds[e.level-1] {
+			end = t.levelBounds[e.level-1]
+		}
+		for pos := end - 1; pos >= first; pos-- {
+			stack = append(stack, entry{pos, e.level - 1})
+		}
+	}
+	return results, nil
+}
+
+// Neighbors returns the k items whose boxes are closest to (x,y), nearest first. Items at the
+// same distance are always returned in the same order for the same tree. If k is greater than
+// the number of items, all the items are returned.
+func (t *Packed) Neighbors(x, y float64, k int) ([]int, error) {
+	if !t.finished {
+		return nil, ErrNotFinished
+	}
+	if len(t.boxes) == 0 || k <= 0 {
+		return nil, nil
+	}
+
+	var results []int
+	q := &nodeQueue{}
+	root := len(t.boxes) - 1
+	heap.Push(q, queued{pos: root, level: len(t.levelBounds) - 1, dist: t.boxes[root].distance2(x, y)})
+
+	for q.Len() > 0 {
+		e := heap.Pop(q).(queued)
+		if e.level < 0 {
+			// An item, which is nearer than anything left in the queue.
+			results = append(results, t.indices[e.pos])
+			if len(results) == k {
+				break
+			}
+			continue
+		}
+
+		if e.level == 0 {
+			heap.Push(q, queued{pos: e.pos, level: -1, dist: e.dist})
+			continue
+		}
+
+		first := t.indices[e.pos]
+		end := first + t.nodeSize
+		if end > t.levelBounds[e.level-1] {
+			end = t.levelBounds[e.level-1]
+		}
+		for pos := first; pos < end; pos++ {
+			heap.Push(q, queued{pos: pos, level: e.level - 1, dist: t.boxes[pos].distance2(x, y)})
+		}
+	}
+	return results, nil
+}
+
+// queued is a node or item waiting in a nodeQueue. Items have a level of -1.
+type queued struct {
+	pos, level int
+	dist       float64
+}
+
+// nodeQueue is a priority queue of nodes and items, nearest first. Items come before nodes at
+// the same distance, and otherwise the order of the tree is kept.
+type nodeQueue []queued
+
+func (q nodeQueue) Len() int { return len(q) }
+func (q nodeQueue) Less(i, j int) bool {
+	if q[i].dist != q[j].dist {
+		return q[i].dist < q[j].dist
+	}
+	if q[i].level != q[j].level {
+		return q[i].level < q[j].level
+	}
+	return q[i].pos < q[j].pos
+}
+func (q nodeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *nodeQueue) Push(x interface{}) { *q = append(*q, x.(queued)) }
+func (q *nodeQueue) Pop() interface{} {
+	old := *q
+	x := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return x
+}
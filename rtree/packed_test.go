@@ -0,0 +1,251 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// randomBoxes returns n small boxes within [0,1000).
+func randomBoxes(r *rand.Rand, n int) []Box {
+	boxes := make([]Box, n)
+	for i := range boxes {
+		x, y := r.Float64()*1000, r.Float64()*1000
+		boxes[i] = Box{x, y, x + r.Float64()*10, y + r.Float64()*10}
+	}
+	return boxes
+}
+
+// bruteSearch returns the indexes of the boxes which intersect b, in increasing order.
+func bruteSearch(boxes []Box, b Box) []int {
+	var results []int
+	for i, o := range boxes {
+		if b.Intersects(o) {
+			results = append(results, i)
+		}
+	}
+	return results
+}
+
+// bruteNeighbors returns the distances of the k boxes nearest (x,y), in increasing order.
+func bruteNeighbors(boxes []Box, x, y float64, k int) []float64 {
+	dists := make([]float64, len(boxes))
+	for i, b := range boxes {
+		dists[i] = b.distance2(x, y)
+	}
+	sort.Float64s(dists)
+	if k < len(dists) {
+		dists = dists[:k]
+	}
+	return dists
+}
+
+func newTestPacked(t testing.TB, nodeSize int, boxes []Box) *Packed {
+	tree, err := NewPacked(nodeSize)
+	if err != nil {
+		t.Fatalf("NewPacked(%d) failed: %s", nodeSize, err)
+	}
+	for i, b := range boxes {
+		if id, err := tree.Add(b); err != nil || id != i {
+			t.Fatalf("Add(%v) = (%d, %v) want (%d, nil)", b, id, err, i)
+		}
+	}
+	if err := tree.Finish(); err != nil {
+		t.Fatalf("Finish() failed: %s", err)
+	}
+	return tree
+}
+
+func TestPackedSearch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 15, 16, 17, 100, 1000} {
+		for _, nodeSize := range []int{2, 4, 16} {
+			boxes := randomBoxes(r, n)
+			tree := newTestPacked(t, nodeSize, boxes)
+			if tree.Len() != n {
+				t.Errorf("n=%d: Len() = %d", n, tree.Len())
+			}
+
+			for i := 0; i < 50; i++ {
+				q := randomBoxes(r, 1)[0]
+				q.MaxX += r.Float64() * 200
+				q.MaxY += r.Float64() * 200
+
+				got, err := tree.Search(q)
+				if err != nil {
+					t.Fatalf("Search(%v) failed: %s", q, err)
+				}
+				sort.Ints(got)
+				want := bruteSearch(boxes, q)
+				if len(got) != len(want) {
+					t.Errorf("n=%d nodeSize=%d: Search(%v) = %v want %v", n, nodeSize, q, got, want)
+					continue
+				}
+				for j := range got {
+					if got[j] != want[j] {
+						t.Errorf("n=%d nodeSize=%d: Search(%v) = %v want %v", n, nodeSize, q, got, want)
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPackedNeighbors(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 17, 1000} {
+		boxes := randomBoxes(r, n)
+		tree := newTestPacked(t, 8, boxes)
+
+		for _, k := range []int{0, 1, 5, 50, 2000} {
+			x, y := r.Float64()*1200-100, r.Float64()*1200-100
+			got, err := tree.Neighbors(x, y, k)
+			if err != nil {
+				t.Fatalf("Neighbors(%v, %v, %d) failed: %s", x, y, k, err)
+			}
+
+			want := bruteNeighbors(boxes, x, y, k)
+			if len(got) != len(want) {
+				t.Errorf("n=%d: Neighbors(%v, %v, %d) returned %d items want %d", n, x, y, k, len(got), len(want))
+				continue
+			}
+			seen := make(map[int]bool)
+			for j, id := range got {
+				if seen[id] {
+					t.Errorf("n=%d: Neighbors(%v, %v, %d) returned %d twice", n, x, y, k, id)
+				}
+				seen[id] = true
+				if d := boxes[id].distance2(x, y); d != want[j] {
+					t.Errorf("n=%d: Neighbors(%v, %v, %d)[%d] = %d at distance %v want %v", n, x, y, k, j, id, d, want[j])
+				}
+			}
+		}
+	}
+}
+
+// TestPackedDeterministic checks the same boxes always build the same tree, even when many
+// share a centre.
+func TestPackedDeterministic(t *testing.T) {
+	var boxes []Box
+	for i := 0; i < 100; i++ {
+		boxes = append(boxes, Box{float64(i % 3), 0, float64(i%3) + 1, 1})
+	}
+
+	a := newTestPacked(t, 4, boxes)
+	b := newTestPacked(t, 4, boxes)
+	q := Box{0, 0, 10, 10}
+	ra, _ := a.Search(q)
+	rb, _ := b.Search(q)
+	na, _ := a.Neighbors(1.5, 0.5, 100)
+	nb, _ := b.Neighbors(1.5, 0.5, 100)
+	for i := range ra {
+		if ra[i] != rb[i] || na[i] != nb[i] {
+			t.Fatalf("trees built from the same boxes differ: %v %v, %v %v", ra, rb, na, nb)
+		}
+	}
+
+	// Items with the same centre keep the order they were added.
+	last := map[int]int{}
+	for _, id := range ra {
+		if prev, ok := last[id%3]; ok && prev > id {
+			t.Errorf("Search returned %d after %d", id, prev)
+		}
+		last[id%3] = id
+	}
+}
+
+func TestPackedErrors(t *testing.T) {
+	if _, err := NewPacked(1); err != ErrNodeSize {
+		t.Errorf("NewPacked(1) = %q want %q", err, ErrNodeSize)
+	}
+
+	tree, err := NewPacked(4)
+	if err != nil {
+		t.Fatalf("NewPacked(4) failed: %s", err)
+	}
+	if _, err := tree.Search(Box{}); err != ErrNotFinished {
+		t.Errorf("Search before Finish = %q want %q", err, ErrNotFinished)
+	}
+	if _, err := tree.Neighbors(0, 0, 1); err != ErrNotFinished {
+		t.Errorf("Neighbors before Finish = %q want %q", err, ErrNotFinished)
+	}
+	if err := tree.Finish(); err != nil {
+		t.Errorf("Finish() = %q want nil", err)
+	}
+	if _, err := tree.Add(Box{}); err != ErrFinished {
+		t.Errorf("Add after Finish = %q want %q", err, ErrFinished)
+	}
+	if err := tree.Finish(); err != ErrFinished {
+		t.Errorf("Finish() twice = %q want %q", err, ErrFinished)
+	}
+}
+
+const benchmarkItems = 100000
+
+func benchmarkSetup(b *testing.B) ([]Box, *Packed, []Box) {
+	r := rand.New(rand.NewSource(1))
+	boxes := randomBoxes(r, benchmarkItems)
+	queries := randomBoxes(r, 1000)
+	for i := range queries {
+		queries[i].MaxX += 10
+		queries[i].MaxY += 10
+	}
+	return boxes, newTestPacked(b, 16, boxes), queries
+}
+
+func BenchmarkPackedFinish(b *testing.B) {
+	boxes := randomBoxes(rand.New(rand.NewSource(1)), benchmarkItems)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newTestPacked(b, 16, boxes)
+	}
+}
+
+func BenchmarkPackedSearch(b *testing.B) {
+	_, tree, queries := benchmarkSetup(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Search(queries[i%len(queries)])
+	}
+}
+
+func BenchmarkBruteSearch(b *testing.B) {
+	boxes, _, queries := benchmarkSetup(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bruteSearch(boxes, queries[i%len(queries)])
+	}
+}
+
+func BenchmarkPackedNeighbors(b *testing.B) {
+	_, tree, queries := benchmarkSetup(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := queries[i%len(queries)]
+		tree.Neighbors(q.MinX, q.MinY, 10)
+	}
+}
+
+func BenchmarkBruteNeighbors(b *testing.B) {
+	boxes, _, queries := benchmarkSetup(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := queries[i%len(queries)]
+		bruteNeighbors(boxes, q.MinX, q.MinY, 10)
+	}
+}
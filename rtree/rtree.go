@@ -0,0 +1,113 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rtree is for indexing boxes in R-trees whose entries are ordered along a Hilbert
+// curve, which keeps nearby boxes in the same nodes.
+//
+// Packed is a static tree, built all at once, and Tree is a dynamic tree which supports
+// inserts and deletes.
+package rtree
+
+import (
+	"errors"
+	"math"
+
+	"github.com/google/hilbert"
+)
+
+// Errors returned by the trees.
+var (
+	ErrNodeSize     = errors.New("node size must be at least two")
+	ErrTreeNodeSize = errors.New("tree node size must be at least four")
+	ErrFinished     = errors.New("index is already finished")
+	ErrNotFinished  = errors.New("index is not finished")
+)
+
+// Box is an axis-aligned box, with corners (MinX,MinY) and (MaxX,MaxY) inclusive.
+type Box struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// emptyBox is the identity for extend.
+var emptyBox = Box{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+
+// Intersects returns true if the boxes share any point.
+func (b Box) Intersects(o Box) bool {
+	return b.MinX <= o.MaxX && o.MinX <= b.MaxX && b.MinY <= o.MaxY && o.MinY <= b.MaxY
+}
+
+// extend returns the smallest box containing both boxes.
+func (b Box) extend(o Box) Box {
+	return Box{
+		MinX: math.Min(b.MinX, o.MinX),
+		MinY: math.Min(b.MinY, o.MinY),
+		MaxX: math.Max(b.MaxX, o.MaxX),
+		MaxY: math.Max(b.MaxY, o.MaxY),
+	}
+}
+
+// distance2 returns the squared distance from (x,y) to the nearest point of the box.
+func (b Box) distance2(x, y float64) float64 {
+	dx := axisDistance(x, b.MinX, b.MaxX)
+	dy := axisDistance(y, b.MinY, b.MaxY)
+	return dx*dx + dy*dy
+}
+
+func axisDistance(k, min, max float64) float64 {
+	if k < min {
+		return min - k
+	}
+	if k > max {
+		return k - max
+	}
+	return 0
+}
+
+// hilbertSize is the width of the grid the centres of the boxes are placed on to order them.
+const hilbertSize = 1 << 16
+
+// curve orders the boxes.
+var curve = func() *hilbert.Hilbert64 {
+	s, err := hilbert.NewHilbert64(hilbertSize)
+	if err != nil {
+		panic("assertion failure: " + err.Error())
+	}
+	return s
+}()
+
+// hilbertValue returns the index on the curve of the centre of b, within the extent bounds.
+func hilbertValue(b, bounds Box) uint64 {
+	x := gridCoord((b.MinX+b.MaxX)/2, bounds.MinX, bounds.MaxX)
+	y := gridCoord((b.MinY+b.MaxY)/2, bounds.MinY, bounds.MaxY)
+	t, err := curve.MapInverse(x, y)
+	if err != nil {
+		panic("assertion failure: " + err.Error())
+	}
+	return t
+}
+
+// gridCoord scales k, within [min,max], to a column or row of the curve's grid.
+func gridCoord(k, min, max float64) uint32 {
+	if !(max > min) {
+		return 0
+	}
+	f := math.Floor((k - min) / (max - min) * (hilbertSize - 1))
+	if !(f >= 0) {
+		return 0
+	}
+	if f >= hilbertSize-1 {
+		return hilbertSize - 1
+	}
+	return uint32(f)
+}
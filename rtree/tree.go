@@ -0,0 +1,355 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// Tree is a dynamic Hilbert R-tree, as described by Kamel and Faloutsos in "Hilbert R-tree: An
+// improved R-tree using fractals" (1994).
+//
+// The items in the leaves are kept in order of the Hilbert value of the centres of their boxes,
+// and each internal entry records the largest value below it, so an item is inserted into the
+// leaf which keeps that order. Instead of splitting a full node in two, its entries are first
+// shared with a sibling, and only when both are full are the two split into three. Likewise a
+// node left too empty by a delete borrows from a sibling, or the two are merged.
+//
+// Any number of goroutines may search the tree at once, while inserts and deletes are done one
+// at a time.
+type Tree struct {
+	mu sync.RWMutex
+
+	extent     Box // The space the Hilbert values are calculated within.
+	maxEntries int
+	minEntries int
+
+	root *node
+	size int
+}
+
+// treeKey orders the items in the tree.
+type treeKey struct {
+	h  uint64 // Hilbert value of the centre of the box.
+	id int
+}
+
+func (k treeKey) less(o treeKey) bool {
+	return k.h < o.h || (k.h == o.h && k.id < o.id)
+}
+
+// treeEntry is an item in a leaf, or a child of an internal node.
+type treeEntry struct {
+	box   Box
+	key   treeKey // For a child, the largest key of the items below it.
+	child *node   // nil in leaves
+}
+
+type node struct {
+	leaf    bool
+	entries []treeEntry
+}
+
+// NewTree returns an empty Hilbert R-tree, whose nodes hold up to nodeSize entries. nodeSize
+// must be at least four, and 16 is a good default.
+//
+// The Hilbert values of the boxes are calculated within extent, which should cover the boxes
+// that will be added. Boxes outside it are still indexed correctly, but their centres are
+// clamped to its edges, so they are not ordered as well.
+func NewTree(extent Box, nodeSize int) (*Tree, error) {
+	if nodeSize < 4 {
+		return nil, ErrTreeNodeSize
+	}
+	minEntries := nodeSize / 3
+	if minEntries < 2 {
+		minEntries = 2
+	}
+	return &Tree{
+		extent:     extent,
+		maxEntries: nodeSize,
+		minEntries: minEntries,
+		root:       &node{leaf: true},
+	}, nil
+}
+
+// Len returns the number of items in the tree.
+func (t *Tree) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+// Insert adds the item id with box b to the tree.
+func (t *Tree) Insert(b Box, id int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e := treeEntry{box: b, key: treeKey{hilbertValue(b, t.extent), id}}
+	t.insert(t.root, e)
+	if len(t.root.entries) > t.maxEntries {
+		// Grow a new root, and split the old one beneath it.
+		t.root = &node{entries: []treeEntry{{child: t.root}}}
+		t.overflow(t.root, 0)
+	}
+	t.size++
+}
+
+// insert adds e to the subtree at n. n may be left with too many entries.
+func (t *Tree) insert(n *node, e treeEntry) {
+	if n.leaf {
+		i := len(n.entries)
+		for i > 0 && e.key.less(n.entries[i-1].key) {
+			i--
+		}
+		n.entries = append(n.entries, treeEntry{})
+		copy(n.entries[i+1:], n.entries[i:])
+		n.entries[i] = e
+		return
+	}
+
+	// Choose the first child whose largest key is after e's, or the last child.
+	i := 0
+	for i < len(n.entries)-1 && n.entries[i].key.less(e.key) {
+		i++
+	}
+	child := n.entries[i].child
+	t.insert(child, e)
+	if len(child.entries) > t.maxEntries {
+		t.overflow(n, i)
+	} else {
+		n.entries[i].refresh()
+	}
+}
+
+// overflow handles child i of n having too many entries, by sharing its entries with a
+// sibling, or if they are both full, splitting the two into three. n may be left with too many
+// entries.
+func (t *Tree) overflow(n *node, i int) {
+	lo, hi := t.siblings(n, i)
+	total := 0
+	for j := lo; j <= hi; j++ {
+		total += len(n.entries[j].child.entries)
+	}
+
+	count := hi - lo + 1
+	if total > count*t.maxEntries {
+		leaf := n.entries[i].child.leaf
+		n.entries = append(n.entries, treeEntry{})
+		copy(n.entries[hi+2:], n.entries[hi+1:])
+		n.entries[hi+1] = treeEntry{child: &node{leaf: leaf}}
+		hi++
+	}
+	t.redistribute(n, lo, hi)
+}
+
+// underflow handles child i of n having too few entries, by taking entries from a sibling, or
+// if the sibling is too empty to spare any, merging the two.
+func (t *Tree) underflow(n *node, i int) {
+	lo, hi := t.siblings(n, i)
+	if lo == hi {
+		n.entries[i].refresh() // Only the root can have a single child.
+		return
+	}
+
+	total := len(n.entries[lo].child.entries) + len(n.entries[hi].child.entries)
+	if total < 2*t.minEntries {
+		n.entries[lo].child.entries = append(n.entries[lo].child.entries, n.entries[hi].child.entries...)
+		n.entries = append(n.entries[:hi], n.entries[hi+1:]...)
+		hi--
+	}
+	t.redistribute(n, lo, hi)
+}
+
+// siblings returns the range of children of n which cooperate with child i: the child and the
+// next one, or the previous one for the last child.
+func (t *Tree) siblings(n *node, i int) (lo, hi int) {
+	switch {
+	case len(n.entries) == 1:
+		return i, i
+	case i == len(n.entries)-1:
+		return i - 1, i
+	default:
+		return i, i + 1
+	}
+}
+
+// redistribute shares the entries of children lo to hi of n evenly between them, keeping their
+// order.
+func (t *Tree) redistribute(n *node, lo, hi int) {
+	var all []treeEntry
+	for j := lo; j <= hi; j++ {
+		all = append(all, n.entries[j].child.entries...)
+	}
+
+	for j := lo; j <= hi; j++ {
+		// Share what is left between the children left.
+		size := (len(all) + hi - j) / (hi - j + 1)
+		child := n.entries[j].child
+		child.entries = append(child.entries[:0:0], all[:size]...)
+		all = all[size:]
+		n.entries[j].refresh()
+	}
+}
+
+// refresh recalculates the box and key of a child entry from the child's entries.
+func (e *treeEntry) refresh() {
+	e.box = emptyBox
+	for _, c := range e.child.entries {
+		e.box = e.box.extend(c.box)
+	}
+	if len(e.child.entries) > 0 {
+		e.key = e.child.entries[len(e.child.entries)-1].key
+	}
+}
+
+// Delete removes the item id with box b from the tree, and returns true if it was found. If the
+// item was inserted more than once, only one is removed.
+func (t *Tree) Delete(b Box, id int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	k := treeKey{hilbertValue(b, t.extent), id}
+	if !t.delete(t.root, b, k) {
+		return false
+	}
+	if !t.root.leaf && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+	t.size--
+	return true
+}
+
+// delete removes the item with box b and key k from the subtree at n, and returns true if it
+// was found. n may be left with too few entries.
+func (t *Tree) delete(n *node, b Box, k treeKey) bool {
+	if n.leaf {
+		for i, e := range n.entries {
+			if e.key == k && e.box == b {
+				n.entries = append(n.entries[:i], n.entries[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+
+	// Only the children whose range of keys includes k need be searched.
+	for i := range n.entries {
+		if n.entries[i].key.less(k) {
+			continue
+		}
+		if i > 0 && k.less(n.entries[i-1].key) {
+			break
+		}
+
+		child := n.entries[i].child
+		if t.delete(child, b, k) {
+			if len(child.entries) < t.minEntries {
+				t.underflow(n, i)
+			} else {
+				n.entries[i].refresh()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Search returns the items whose boxes intersect b, in order of the Hilbert values of their
+// centres.
+func (t *Tree) Search(b Box) []int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var results []int
+	var search func(n *node)
+	search = func(n *node) {
+		for _, e := range n.entries {
+			if !b.Intersects(e.box) {
+				continue
+			}
+			if n.leaf {
+				results = append(results, e.key.id)
+			} else {
+				search(e.child)
+			}
+		}
+	}
+	search(t.root)
+	return results
+}
+
+// Neighbors returns the k items whose boxes are closest to (x,y), nearest first. Items at the
+// same distance are always returned in the same order for the same tree. If k is greater than
+// the number of items, all the items are returned.
+func (t *Tree) Neighbors(x, y float64, k int) []int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if k <= 0 {
+		return nil
+	}
+
+	var results []int
+	q := &treeQueue{}
+	heap.Push(q, treeQueued{child: t.root})
+
+	for q.Len() > 0 {
+		e := heap.Pop(q).(treeQueued)
+		if e.child == nil {
+			// An item, which is nearer than anything left in the queue.
+			results = append(results, e.key.id)
+			if len(results) == k {
+				break
+			}
+			continue
+		}
+
+		for _, c := range e.child.entries {
+			heap.Push(q, treeQueued{child: c.child, key: c.key, dist: c.box.distance2(x, y)})
+		}
+	}
+	return results
+}
+
+// treeQueued is a node or item waiting in a treeQueue. Items have no child.
+type treeQueued struct {
+	child *node
+	key   treeKey // The item's key, or the largest key below the node.
+	dist  float64
+}
+
+// treeQueue is a priority queue of nodes and items, nearest first. Items come before nodes at
+// the same distance, and otherwise they are in key order.
+type treeQueue []treeQueued
+
+func (q treeQueue) Len() int { return len(q) }
+func (q treeQueue) Less(i, j int) bool {
+	if q[i].dist != q[j].dist {
+		return q[i].dist < q[j].dist
+	}
+	if (q[i].child == nil) != (q[j].child == nil) {
+		return q[i].child == nil
+	}
+	return q[i].key.less(q[j].key)
+}
+func (q treeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *treeQueue) Push(x interface{}) { *q = append(*q, x.(treeQueued)) }
+func (q *treeQueue) Pop() interface{} {
+	old := *q
+	x := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+var testExtent = Box{0, 0, 1000, 1000}
+
+// checkTree checks the invariants of the tree: every leaf is at the same depth, every node but
+// the root has between minEntries and maxEntries entries, every child's box and key match its
+// entries, and the items are in key order.
+func checkTree(t *testing.T, tree *Tree) {
+	t.Helper()
+
+	depth := -1
+	count := 0
+	var last *treeKey
+	var check func(n *node, level int, root bool)
+	check = func(n *node, level int, root bool) {
+		if !root && (len(n.entries) < tree.minEntries || len(n.entries) > tree.maxEntries) {
+			t.Fatalf("node at level %d has %d entries, want [%d, %d]", level, len(n.entries), tree.minEntries, tree.maxEntries)
+		}
+		if n.leaf {
+			if depth >= 0 && depth != level {
+				t.Fatalf("leaves at depths %d and %d", depth, level)
+			}
+			depth = level
+			for i := range n.entries {
+				k := n.entries[i].key
+				if last != nil && k.less(*last) {
+					t.Fatalf("item %+v after %+v", k, *last)
+				}
+				last = &n.entries[i].key
+				count++
+			}
+			return
+		}
+		if root && len(n.entries) < 2 {
+			t.Fatalf("internal root has %d entries", len(n.entries))
+		}
+		for _, e := range n.entries {
+			want := e
+			want.refresh()
+			if want.box != e.box || want.key != e.key {
+				t.Fatalf("child entry %+v %+v want %+v %+v", e.box, e.key, want.box, want.key)
+			}
+			if e.child.leaf != n.entries[0].child.leaf {
+				t.Fatalf("children of a node are not all leaves or all internal")
+			}
+			check(e.child, level+1, false)
+		}
+	}
+	check(tree.root, 0, true)
+
+	if count != tree.Len() {
+		t.Fatalf("tree has %d items, Len() = %d", count, tree.Len())
+	}
+}
+
+// TestTreeOracle checks the tree against a brute force search, through thousands of random
+// inserts and deletes.
+func TestTreeOracle(t *testing.T) {
+	for _, nodeSize := range []int{4, 5, 9, 16} {
+		r := rand.New(rand.NewSource(int64(nodeSize)))
+		tree, err := NewTree(testExtent, nodeSize)
+		if err != nil {
+			t.Fatalf("NewTree(%d) failed: %s", nodeSize, err)
+		}
+
+		items := make(map[int]Box)
+		nextID := 0
+		for step := 0; step < 5000; step++ {
+			// Grow the tree for a while, and then shrink it.
+			grow := step < 3000
+			if len(items) > 0 && (r.Intn(3) == 0 || !grow && r.Intn(4) != 0) {
+				// Delete a random item
+				ids := make([]int, 0, len(items))
+				for id := range items {
+					ids = append(ids, id)
+				}
+				sort.Ints(ids)
+				id := ids[r.Intn(len(ids))]
+				if !tree.Delete(items[id], id) {
+					t.Fatalf("nodeSize=%d: Delete(%v, %d) = false want true", nodeSize, items[id], id)
+				}
+				delete(items, id)
+			} else {
+				b := randomBoxes(r, 1)[0]
+				if r.Intn(10) == 0 {
+					b = Box{500, 500, 500, 500} // Many items with the same centre
+				}
+				tree.Insert(b, nextID)
+				items[nextID] = b
+				nextID++
+			}
+
+			if step%100 == 0 {
+				checkTree(t, tree)
+				checkTreeQueries(t, r, tree, items)
+			}
+		}
+
+		// Deleting an item not in the tree.
+		if tree.Delete(Box{1, 2, 3, 4}, -1) {
+			t.Errorf("nodeSize=%d: Delete of a missing item = true want false", nodeSize)
+		}
+		checkTree(t, tree)
+		checkTreeQueries(t, r, tree, items)
+	}
+}
+
+// checkTreeQueries checks some random searches against a brute force search of items.
+func checkTreeQueries(t *testing.T, r *rand.Rand, tree *Tree, items map[int]Box) {
+	t.Helper()
+
+	for i := 0; i < 10; i++ {
+		q := randomBoxes(r, 1)[0]
+		q.MaxX += r.Float64() * 100
+		q.MaxY += r.Float64() * 100
+
+		var want []int
+		for id, b := range items {
+			if q.Intersects(b) {
+				want = append(want, id)
+			}
+		}
+		sort.Ints(want)
+		got := tree.Search(q)
+		sort.Ints(got)
+		if len(got) != len(want) {
+			t.Fatalf("Search(%v) = %v want %v", q, got, want)
+		}
+		for j := range got {
+			if got[j] != want[j] {
+				t.Fatalf("Search(%v) = %v want %v", q, got, want)
+			}
+		}
+
+		x, y := r.Float64()*1000, r.Float64()*1000
+		k := 1 + r.Intn(20)
+		var dists []float64
+		for _, b := range items {
+			dists = append(dists, b.distance2(x, y))
+		}
+		sort.Float64s(dists)
+		if k < len(dists) {
+			dists = dists[:k]
+		}
+		neighbors := tree.Neighbors(x, y, k)
+		if len(neighbors) != len(dists) {
+			t.Fatalf("Neighbors(%v, %v, %d) returned %d items want %d", x, y, k, len(neighbors), len(dists))
+		}
+		for j, id := range neighbors {
+			if d := items[id].distance2(x, y); d != dists[j] {
+				t.Fatalf("Neighbors(%v, %v, %d)[%d] = %d at distance %v want %v", x, y, k, j, id, d, dists[j])
+			}
+		}
+	}
+}
+
+// TestTreeConcurrent checks searches can run while the tree is being changed.
+func TestTreeConcurrent(t *testing.T) {
+	tree, err := NewTree(testExtent, 8)
+	if err != nil {
+		t.Fatalf("NewTree(8) failed: %s", err)
+	}
+	boxes := randomBoxes(rand.New(rand.NewSource(1)), 2000)
+
+	var wg sync.WaitGroup
+	done := make(chan bool)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				q := randomBoxes(r, 1)[0]
+				q.MaxX += 50
+				q.MaxY += 50
+				tree.Search(q)
+				tree.Neighbors(q.MinX, q.MinY, 5)
+			}
+		}(int64(i))
+	}
+
+	for i, b := range boxes {
+		tree.Insert(b, i)
+	}
+	for i, b := range boxes[:1000] {
+		tree.Delete(b, i)
+	}
+	close(done)
+	wg.Wait()
+
+	if tree.Len() != 1000 {
+		t.Errorf("Len() = %d want 1000", tree.Len())
+	}
+	checkTree(t, tree)
+}
+
+func TestNewTreeErrors(t *testing.T) {
+	if _, err := NewTree(testExtent, 3); err != ErrTreeNodeSize {
+		t.Errorf("NewTree(3) = %q want %q", err, ErrTreeNodeSize)
+	}
+	tree, err := NewTree(testExtent, 4)
+	if err != nil {
+		t.Fatalf("NewTree(4) failed: %s", err)
+	}
+	if got := tree.Search(testExtent); len(got) != 0 {
+		t.Errorf("Search on an empty tree = %v", got)
+	}
+	if got := tree.Neighbors(0, 0, 3); len(got) != 0 {
+		t.Errorf("Neighbors on an empty tree = %v", got)
+	}
+}
+
+func newBenchmarkTree(b *testing.B, boxes []Box) *Tree {
+	tree, err := NewTree(testExtent, 16)
+	if err != nil {
+		b.Fatalf("NewTree(16) failed: %s", err)
+	}
+	for i, box := range boxes {
+		tree.Insert(box, i)
+	}
+	return tree
+}
+
+func BenchmarkTreeInsert(b *testing.B) {
+	boxes := randomBoxes(rand.New(rand.NewSource(1)), benchmarkItems)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newBenchmarkTree(b, boxes)
+	}
+}
+
+func BenchmarkTreeSearch(b *testing.B) {
+	boxes, _, queries := benchmarkSetup(b)
+	tree := newBenchmarkTree(b, boxes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Search(queries[i%len(queries)])
+	}
+}
+
+func BenchmarkTreeNeighbors(b *testing.B) {
+	boxes, _, queries := benchmarkSetup(b)
+	tree := newBenchmarkTree(b, boxes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := queries[i%len(queries)]
+		tree.Neighbors(q.MinX, q.MinY, 10)
+	}
+}
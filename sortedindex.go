@@ -0,0 +1,242 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"math"
+	"sort"
+)
+
+// SortedIndex finds the nearest neighbours of a point, among a set of points sorted by their
+// index on a curve. Points close together in the space are usually close together on the
+// curve, so looking at a window of points either side of a query's own index finds most of its
+// nearest neighbours cheaply.
+type SortedIndex struct {
+	curve SpaceFilling
+
+	// The points, their indexes on the curve, and their positions in the slice the index was
+	// created from, all sorted by the index on the curve.
+	points []Point
+	ts     []int
+	ids    []int
+}
+
+// NewSortedIndex returns an index of the points on the curve. The points need not be sorted,
+// and the slice is not modified. Results refer to points by their position in the slice.
+func NewSortedIndex(curve SpaceFilling, points []Point) (*SortedIndex, error) {
+	idx := &SortedIndex{
+		curve:  curve,
+		points: make([]Point, len(points)),
+		ts:     make([]int, len(points)),
+		ids:    make([]int, len(points)),
+	}
+
+	order := make([]int, len(points))
+	ts := make([]int, len(points))
+	for i, p := range points {
+		t, err := curve.MapInverse(p.X, p.Y)
+		if err != nil {
+			return nil, err
+		}
+		order[i], ts[i] = i, t
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		return ts[a] < ts[b] || (ts[a] == ts[b] && a < b)
+	})
+
+	for i, id := range order {
+		idx.points[i] = points[id]
+		idx.ts[i] = ts[id]
+		idx.ids[i] = id
+	}
+	return idx, nil
+}
+
+// Len returns the number of points in the index.
+func (idx *SortedIndex) Len() int {
+	return len(idx.points)
+}
+
+// neighbor is a candidate nearest neighbour.
+type neighbor struct {
+	dist int64 // squared
+	id   int
+}
+
+// NearestApprox returns up to k points near (x,y), nearest first, looking only at the window
+// points either side of (x,y)'s index on the curve. It is fast, but can miss neighbours that are
+// close in the space but far along the curve, such as those on the other side of the boundary
+// between two large quadrants. Points at the same distance are in the order they were given.
+func (idx *SortedIndex) NearestApprox(x, y, k, window int) ([]int, error) {
+	pos, err := idx.search(x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	lo, hi := idx.window(pos, window)
+	return ids(best(k, idx.scan(x, y, nil, lo, hi))), nil
+}
+
+// Nearest returns the k points nearest (x,y), nearest first. Points at the same distance are in
+// the order they were given.
+//
+// The neighbours found by a window of the curve around (x,y) give an upper bound on the
+// distance to the kth neighbour. Every point within that distance is inside a box around
+// (x,y), so the points in the ranges of the curve covering that box are checked too. At most
+// nearestMaxRanges ranges are used, so on sparse data, where the box can be nearly as large as
+// the space, the ranges cover more than the box rather than taking time and memory
+// proportional to its size. Ranges are only available for the Hilbert, Morton and Moore curves,
+// so for any other curve, or when there are few points or the box covers much of the space, all
+// the points are checked instead.
+func (idx *SortedIndex) Nearest(x, y, k int) ([]int, error) {
+	pos, err := idx.search(x, y)
+	if err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+
+	// Find an upper bound on the distance from a window of the curve.
+	lo, hi := idx.window(pos, k)
+	candidates := best(k, idx.scan(x, y, nil, lo, hi))
+	if len(candidates) < k {
+		// Fewer than k points in the index, which are all in the window.
+		return ids(candidates), nil
+	}
+	dist := candidates[k-1].dist
+	r := int(math.Sqrt(float64(dist)))
+	for int64(r)*int64(r) < dist {
+		r++
+	}
+
+	if len(idx.points) <= nearestMaxRanges || idx.large(x, y, r) {
+		return ids(best(k, idx.scan(x, y, nil, 0, len(idx.points)))), nil
+	}
+	ranges, err := Ranges(idx.curve, x-r, y-r, x+r, y+r, nearestMaxRanges)
+	if err == ErrNotSupported {
+		return ids(best(k, idx.scan(x, y, nil, 0, len(idx.points)))), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var all []neighbor
+	for _, rg := range ranges {
+		start := sort.SearchInts(idx.ts, rg.Start)
+		end := sort.SearchInts(idx.ts, rg.End+1)
+		all = idx.scan(x, y, all, start, end)
+	}
+	return ids(best(k, all)), nil
+}
+
+// nearestMaxRanges is the most ranges of the curve Nearest checks. More ranges cover the box
+// more closely, but each needs two binary searches of the index.
+const nearestMaxRanges = 32
+
+// large returns true if the box of radius r around (x,y), clipped to the space, covers more
+// than a quarter of it. The ranges covering such a box hold most of the points anyway.
+func (idx *SortedIndex) large(x, y, r int) bool {
+	width, height := idx.curve.GetDimensions()
+	return float64(clippedSpan(x, r, width))*float64(clippedSpan(y, r, height)) > float64(width)*float64(height)/4
+}
+
+// clippedSpan returns the number of the values [0,n) within r of v.
+func clippedSpan(v, r, n int) int {
+	lo, hi := v-r, v+r
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > n-1 {
+		hi = n - 1
+	}
+	return hi - lo + 1
+}
+
+// search returns the position in the index of the first point at or after (x,y) on the curve.
+func (idx *SortedIndex) search(x, y int) (int, error) {
+	t, err := idx.curve.MapInverse(x, y)
+	if err != nil {
+		return 0, err
+	}
+	return sort.SearchInts(idx.ts, t), nil
+}
+
+// window returns the range of positions of the w points either side of pos.
+func (idx *SortedIndex) window(pos, w int) (lo, hi int) {
+	lo, hi = pos-w, pos+w
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > len(idx.points) {
+		hi = len(idx.points)
+	}
+	return lo, hi
+}
+
+// scan appends the points at positions [lo,hi) to candidates.
+func (idx *SortedIndex) scan(x, y int, candidates []neighbor, lo, hi int) []neighbor {
+	for i := lo; i < hi; i++ {
+		candidates = append(candidates, neighbor{distance2(x, y, idx.points[i]), idx.ids[i]})
+	}
+	return candidates
+}
+
+// best returns the k nearest candidates, nearest first.
+func best(k int, candidates []neighbor) []neighbor {
+	if k <= 0 {
+		return nil
+	}
+
+	// Insertion sort into the k best so far, which is quick as most candidates are rejected.
+	var top []neighbor
+	for _, c := range candidates {
+		if len(top) == k && !c.less(top[k-1]) {
+			continue
+		}
+		if len(top) < k {
+			top = append(top, c)
+		}
+		i := len(top) - 1
+		for ; i > 0 && c.less(top[i-1]); i-- {
+			top[i] = top[i-1]
+		}
+		top[i] = c
+	}
+	return top
+}
+
+// less returns true if n is nearer than o, or at the same distance and given first.
+func (n neighbor) less(o neighbor) bool {
+	return n.dist < o.dist || (n.dist == o.dist && n.id < o.id)
+}
+
+// ids returns the ids of the candidates.
+func ids(candidates []neighbor) []int {
+	if len(candidates) == 0 {
+		return nil
+	}
+	ids := make([]int, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.id
+	}
+	return ids
+}
+
+// distance2 returns the squared distance between (x,y) and p.
+func distance2(x, y int, p Point) int64 {
+	dx, dy := int64(x-p.X), int64(y-p.Y)
+	return dx*dx + dy*dy
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// randomPoints returns n random points in a space of width n, with some duplicates.
+func randomPoints(r *rand.Rand, count, n int) []Point {
+	points := make([]Point, count)
+	for i := range points {
+		if i > 0 && r.Intn(20) == 0 {
+			points[i] = points[r.Intn(i)]
+			continue
+		}
+		points[i] = Point{r.Intn(n), r.Intn(n)}
+	}
+	return points
+}
+
+// bruteNearest returns the k points nearest (x,y), nearest first and then in order.
+func bruteNearest(points []Point, x, y, k int) []int {
+	ids := make([]int, len(points))
+	for i := range ids {
+		ids[i] = i
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		return distance2(x, y, points[ids[i]]) < distance2(x, y, points[ids[j]])
+	})
+	if k < len(ids) {
+		ids = ids[:k]
+	}
+	return ids
+}
+
+func sortedIndexCurves(t testing.TB) []SpaceFilling {
+	h, err := NewHilbert(64)
+	if err != nil {
+		t.Fatalf("NewHilbert(64) failed: %s", err)
+	}
+	m, err := NewMorton(64)
+	if err != nil {
+		t.Fatalf("NewMorton(64) failed: %s", err)
+	}
+	moore, err := NewMoore(64)
+	if err != nil {
+		t.Fatalf("NewMoore(64) failed: %s", err)
+	}
+	p, err := NewPeano(81)
+	if err != nil {
+		t.Fatalf("NewPeano(81) failed: %s", err)
+	}
+	return []SpaceFilling{h, m, moore, p}
+}
+
+func TestSortedIndexNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, curve := range sortedIndexCurves(t) {
+		n, _ := curve.GetDimensions()
+		for _, count := range []int{0, 1, 5, 500} {
+			points := randomPoints(r, count, n)
+			idx, err := NewSortedIndex(curve, points)
+			if err != nil {
+				t.Fatalf("%T: NewSortedIndex failed: %s", curve, err)
+			}
+			if idx.Len() != count {
+				t.Errorf("%T: Len() = %d want %d", curve, idx.Len(), count)
+			}
+
+			for i := 0; i < 50; i++ {
+				x, y, k := r.Intn(n), r.Intn(n), r.Intn(20)
+				want := bruteNearest(points, x, y, k)
+
+				got, err := idx.Nearest(x, y, k)
+				if err != nil {
+					t.Fatalf("%T: Nearest(%d, %d, %d) failed: %s", curve, x, y, k, err)
+				}
+				if fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("%T: Nearest(%d, %d, %d) = %v want %v", curve, x, y, k, got, want)
+				}
+
+				// A window over every point is exact.
+				got, err = idx.NearestApprox(x, y, k, count)
+				if err != nil {
+					t.Fatalf("%T: NearestApprox(%d, %d, %d, %d) failed: %s", curve, x, y, k, count, err)
+				}
+				if fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("%T: NearestApprox(%d, %d, %d, %d) = %v want %v", curve, x, y, k, count, got, want)
+				}
+
+				// A small window returns the nearest of the points it sees.
+				got, err = idx.NearestApprox(x, y, k, 4)
+				if err != nil {
+					t.Fatalf("%T: NearestApprox(%d, %d, %d, 4) failed: %s", curve, x, y, k, err)
+				}
+				if len(got) > k || len(got) > 8 {
+					t.Errorf("%T: NearestApprox(%d, %d, %d, 4) returned %d points", curve, x, y, k, len(got))
+				}
+				for j := 1; j < len(got); j++ {
+					if distance2(x, y, points[got[j-1]]) > distance2(x, y, points[got[j]]) {
+						t.Errorf("%T: NearestApprox(%d, %d, %d, 4) = %v not nearest first", curve, x, y, k, got)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSortedIndexNearestSparse(t *testing.T) {
+	// A few points spread over a large curve put the kth neighbour far away, which must not
+	// need ranges covering every cell of the box around it.
+	n := 1 << 28
+	if uintSize == 32 {
+		n = 1 << 15
+	}
+	far := n / 13
+	curve, err := NewHilbert(n)
+	if err != nil {
+		t.Fatalf("NewHilbert(%d) failed: %s", n, err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	var clustered []Point
+	for i := 0; i < 100; i++ {
+		clustered = append(clustered, Point{r.Intn(1000), r.Intn(1000)})
+		clustered = append(clustered, Point{far + r.Intn(1000), r.Intn(1000)})
+	}
+
+	for _, tc := range []struct {
+		points []Point
+		k      int
+	}{
+		{[]Point{{100, 100}, {far, 100}}, 2},
+		{clustered, 10},
+		{clustered, 150},
+	} {
+		idx, err := NewSortedIndex(curve, tc.points)
+		if err != nil {
+			t.Fatalf("NewSortedIndex failed: %s", err)
+		}
+		got, err := idx.Nearest(100, 100, tc.k)
+		if err != nil {
+			t.Fatalf("Nearest(100, 100, %d) failed: %s", tc.k, err)
+		}
+		if want := bruteNearest(tc.points, 100, 100, tc.k); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%d points: Nearest(100, 100, %d) = %v want %v", len(tc.points), tc.k, got, want)
+		}
+	}
+}
+
+func TestSortedIndexErrors(t *testing.T) {
+	s, err := NewHilbert(16)
+	if err != nil {
+		t.Fatalf("NewHilbert(16) failed: %s", err)
+	}
+	if _, err := NewSortedIndex(s, []Point{{1, 2}, {16, 0}}); err != ErrOutOfRange {
+		t.Errorf("NewSortedIndex with a point outside the space = %q want %q", err, ErrOutOfRange)
+	}
+
+	idx, err := NewSortedIndex(s, []Point{{1, 2}})
+	if err != nil {
+		t.Fatalf("NewSortedIndex failed: %s", err)
+	}
+	if _, err := idx.Nearest(-1, 0, 1); err != ErrOutOfRange {
+		t.Errorf("Nearest(-1, 0, 1) = %q want %q", err, ErrOutOfRange)
+	}
+	if _, err := idx.NearestApprox(0, 16, 1, 1); err != ErrOutOfRange {
+		t.Errorf("NearestApprox(0, 16, 1, 1) = %q want %q", err, ErrOutOfRange)
+	}
+}
+
+// benchmarkSortedIndex returns an index of random points on a Hilbert curve, the points, and
+// some queries.
+func benchmarkSortedIndex(b *testing.B) (*SortedIndex, []Point, []Point) {
+	s, err := NewHilbert(1 << 12)
+	if err != nil {
+		b.Fatalf("NewHilbert failed: %s", err)
+	}
+	r := rand.New(rand.NewSource(1))
+	points := randomPoints(r, 100000, s.N)
+	idx, err := NewSortedIndex(s, points)
+	if err != nil {
+		b.Fatalf("NewSortedIndex failed: %s", err)
+	}
+	return idx, points, randomPoints(r, 100, s.N)
+}
+
+// BenchmarkNearestApprox reports the recall, the fraction of the true 10 nearest neighbours
+// found, for windows of different widths.
+func BenchmarkNearestApprox(b *testing.B) {
+	idx, points, queries := benchmarkSortedIndex(b)
+	const k = 10
+
+	want := make([]map[int]bool, len(queries))
+	for i, q := range queries {
+		want[i] = make(map[int]bool)
+		for _, id := range bruteNearest(points, q.X, q.Y, k) {
+			want[i][id] = true
+		}
+	}
+
+	for _, window := range []int{10, 40, 160, 640, 2560} {
+		b.Run(fmt.Sprintf("window=%d", window), func(b *testing.B) {
+			found := 0
+			for i, q := range queries {
+				got, _ := idx.NearestApprox(q.X, q.Y, k, window)
+				for _, id := range got {
+					if want[i][id] {
+						found++
+					}
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				q := queries[i%len(queries)]
+				idx.NearestApprox(q.X, q.Y, k, window)
+			}
+			b.ReportMetric(float64(found)/float64(k*len(queries)), "recall")
+		})
+	}
+}
+
+func BenchmarkNearest(b *testing.B) {
+	idx, _, queries := benchmarkSortedIndex(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := queries[i%len(queries)]
+		idx.Nearest(q.X, q.Y, 10)
+	}
+}
+
+func BenchmarkNearestBrute(b *testing.B) {
+	_, points, queries := benchmarkSortedIndex(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := queries[i%len(queries)]
+		bruteNearest(points, q.X, q.Y, 10)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+// Direction is the move the curve makes from one point to the next.
+type Direction int
+
+// The directions a curve can move in.
+const (
+	Right Direction = iota // x+1
+	Up                     // y+1
+	Left                   // x-1
+	Down                   // y-1
+	Jump                   // Any move which is not to an adjacent point.
+)
+
+var directionNames = [...]string{"Right", "Up", "Left", "Down", "Jump"}
+
+func (d Direction) String() string {
+	if d < 0 || int(d) >= len(directionNames) {
+		return "Direction(?)"
+	}
+	return directionNames[d]
+}
+
+// Delta returns the change in x and y of a move in direction d. It is zero for a Jump.
+func (d Direction) Delta() (dx, dy int) {
+	switch d {
+	case Right:
+		return 1, 0
+	case Up:
+		return 0, 1
+	case Left:
+		return -1, 0
+	case Down:
+		return 0, -1
+	}
+	return 0, 0
+}
+
+// direction returns the direction of the move (dx,dy).
+func direction(dx, dy int) Direction {
+	switch {
+	case dx == 1 && dy == 0:
+		return Right
+	case dx == 0 && dy == 1:
+		return Up
+	case dx == -1 && dy == 0:
+		return Left
+	case dx == 0 && dy == -1:
+		return Down
+	}
+	return Jump
+}
+
+// Stepper walks along a curve one point at a time, returning the direction of each move. This
+// is useful for plotting, or for streaming through the space in curve order.
+//
+// For Hilbert and Peano curves each step costs O(1) on average. The Stepper keeps the digits of
+// t and the orientation of the square at each level of the curve, and a step only changes the
+// levels below the lowest digit which does not carry. For other curves each step calls Map.
+type Stepper struct {
+	curve SpaceFilling
+	t     int
+	size  int // Number of points on the curve.
+	x, y  int
+
+	// For Hilbert and Peano curves, the layout of the squares at each level, and for each
+	// level, the current digit of t and the orientation of the square.
+	layout  *curveLayout
+	digits  []int
+	orients []int
+}
+
+// NewStepper returns a Stepper at the start of the curve.
+func NewStepper(curve SpaceFilling) (*Stepper, error) {
+	x, y, err := curve.Map(0)
+	if err != nil {
+		return nil, err
+	}
+	w, h := curve.GetDimensions()
+	s := &Stepper{
+		curve: curve,
+		size:  w * h,
+		x:     x,
+		y:     y,
+	}
+
+	switch c := curve.(type) {
+	case *Hilbert:
+		s.initLayout(hilbertLayout, c.N)
+	case *Peano:
+		s.initLayout(peanoLayout, c.N)
+	}
+	return s, nil
+}
+
+// initLayout sets up the digits and axes for a curve of width n, at t = 0.
+func (s *Stepper) initLayout(layout *curveLayout, n int) {
+	s.layout = layout
+	orient := 0
+	for size := n * n; size > 1; size /= layout.base {
+		s.digits = append(s.digits, 0)
+		s.orients = append(s.orients, orient)
+		orient = layout.squares[orient][0].orient
+	}
+}
+
+// Index returns the index of the current point on the curve.
+func (s *Stepper) Index() int {
+	return s.t
+}
+
+// Position returns the coordinates of the current point.
+func (s *Stepper) Position() (x, y int) {
+	return s.x, s.y
+}
+
+// Next moves to the next point on the curve, and returns the direction of the move. It returns
+// false, and does not move, at the end of the curve.
+func (s *Stepper) Next() (Direction, bool) {
+	if s.t+1 >= s.size {
+		return Jump, false
+	}
+	s.t++
+
+	if s.layout == nil {
+		x, y, err := s.curve.Map(s.t)
+		if err != nil {
+			panic("assertion failure: " + err.Error())
+		}
+		d := direction(x-s.x, y-s.y)
+		s.x, s.y = x, y
+		return d, true
+	}
+
+	// Find the lowest level whose digit does not carry. The move is between two of its
+	// squares, and the levels below it start again at their first square.
+	l := len(s.digits) - 1
+	for s.digits[l] == s.layout.base-1 {
+		s.digits[l] = 0
+		l--
+	}
+	d := s.digits[l]
+	s.digits[l]++
+
+	sq := s.layout.squares[s.orients[l]][d]
+	dx, dy := sq.dx, sq.dy
+	s.x, s.y = s.x+dx, s.y+dy
+
+	for i := l + 1; i < len(s.orients); i++ {
+		s.orients[i] = s.layout.squares[s.orients[i-1]][s.digits[i-1]].orient
+	}
+	return direction(dx, dy), true
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hilbert
+
+import "testing"
+
+func TestStepper(t *testing.T) {
+	var curves []SpaceFilling
+	add := func(s SpaceFilling, err error) {
+		if err != nil {
+			t.Fatalf("creating curve failed: %s", err)
+		}
+		curves = append(curves, s)
+	}
+	for _, n := range []int{1, 2, 4, 8, 64} {
+		add(NewHilbert(n))
+		add(NewMorton(n))
+	}
+	for _, n := range []int{1, 3, 9, 81} {
+		add(NewPeano(n))
+	}
+	add(NewHilbertTable(64, 4))
+	add(NewMoore(16))
+	add(NewHilbertRect(7, 5))
+
+	for _, curve := range curves {
+		w, h := curve.GetDimensions()
+		s, err := NewStepper(curve)
+		if err != nil {
+			t.Fatalf("%T %dx%d: NewStepper failed: %s", curve, w, h, err)
+		}
+
+		// Follow the moves from the start, and check they always lead to Map(t).
+		x, y, _ := curve.Map(0)
+		for i := 1; i < w*h; i++ {
+			d, ok := s.Next()
+			if !ok {
+				t.Fatalf("%T %dx%d: Next() ended at %d want %d", curve, w, h, i, w*h)
+			}
+			wantX, wantY, _ := curve.Map(i)
+			if d == Jump {
+				if abs(wantX-x)+abs(wantY-y) == 1 {
+					t.Errorf("%T %dx%d: Next() at %d = Jump to adjacent point", curve, w, h, i)
+				}
+				x, y = s.Position()
+			} else {
+				dx, dy := d.Delta()
+				x, y = x+dx, y+dy
+			}
+
+			if x != wantX || y != wantY {
+				t.Fatalf("%T %dx%d: moved to (%d,%d) at %d want (%d,%d)", curve, w, h, x, y, i, wantX, wantY)
+			}
+			if px, py := s.Position(); px != wantX || py != wantY {
+				t.Fatalf("%T %dx%d: Position() = (%d,%d) at %d want (%d,%d)", curve, w, h, px, py, i, wantX, wantY)
+			}
+			if got := s.Index(); got != i {
+				t.Fatalf("%T %dx%d: Index() = %d want %d", curve, w, h, got, i)
+			}
+		}
+
+		if d, ok := s.Next(); ok {
+			t.Errorf("%T %dx%d: Next() at the end = %s, true want false", curve, w, h, d)
+		}
+		if got := s.Index(); got != w*h-1 {
+			t.Errorf("%T %dx%d: Index() at the end = %d want %d", curve, w, h, got, w*h-1)
+		}
+	}
+}
+
+func TestStepperContinuous(t *testing.T) {
+	h, _ := NewHilbert(32)
+	p, _ := NewPeano(27)
+	m, _ := NewMoore(32)
+
+	for _, curve := range []SpaceFilling{h, p, m} {
+		s, err := NewStepper(curve)
+		if err != nil {
+			t.Fatalf("%T: NewStepper failed: %s", curve, err)
+		}
+		for d, ok := s.Next(); ok; d, ok = s.Next() {
+			if d == Jump {
+				t.Fatalf("%T: Next() at %d = Jump want a unit move", curve, s.Index())
+			}
+		}
+	}
+}
+
+func TestDirection(t *testing.T) {
+	for _, d := range []Direction{Right, Up, Left, Down, Jump} {
+		dx, dy := d.Delta()
+		if got := direction(dx, dy); got != d {
+			t.Errorf("direction(%s.Delta()) = %s want %s", d, got, d)
+		}
+	}
+	if got := Direction(-1).String(); got != "Direction(?)" {
+		t.Errorf("Direction(-1).String() = %q want %q", got, "Direction(?)")
+	}
+}
+
+func BenchmarkStepperHilbert(b *testing.B) {
+	h, _ := NewHilbert(1024)
+	benchmarkStepper(b, h)
+}
+
+func BenchmarkStepperPeano(b *testing.B) {
+	p, _ := NewPeano(729)
+	benchmarkStepper(b, p)
+}
+
+func BenchmarkStepperMoore(b *testing.B) {
+	m, _ := NewMoore(1024)
+	benchmarkStepper(b, m)
+}
+
+func benchmarkStepper(b *testing.B, curve SpaceFilling) {
+	s, _ := NewStepper(curve)
+	for i := 0; i < b.N; i++ {
+		if _, ok := s.Next(); !ok {
+			s, _ = NewStepper(curve)
+		}
+	}
+}